@@ -0,0 +1,113 @@
+package radixs
+
+import (
+	"unsafe"
+)
+
+// bytesView returns a string sharing the memory of b, which must not be
+// modified while the string is in use nor retained by the tree
+func bytesView(b []byte) (s string) {
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// SetBytes is like Set, but takes the key as a byte slice, which is copied
+func (t *Tree) SetBytes(key []byte, value interface{}) (err error) {
+	return t.Set(string(key), value)
+}
+
+// SetWithParamsBytes is like SetWithParams, but takes the key as a byte slice, which is copied
+func (t *Tree) SetWithParamsBytes(key []byte, value interface{}) (err error) {
+	return t.SetWithParams(string(key), value)
+}
+
+// GetBytes is like Get, but takes the key as a byte slice without allocating
+func (t *Tree) GetBytes(key []byte) (value interface{}, err error) {
+	_, value, err = t.GetCanonical(bytesView(key))
+	return value, err
+}
+
+// GetWithParamsBytes is like GetWithParams, but takes the key as a byte slice.
+// The key is copied once when parameters are found, as their values must outlive it.
+// Unlike GetWithParams, params is only written when the lookup succeeds.
+func (t *Tree) GetWithParamsBytes(key []byte, params map[string]string) (value interface{}, err error) {
+	found := map[string]string{}
+	n, err := t.getWithParams(bytesView(key), found)
+
+	// parameter values share the key memory, match again on a copy
+	if len(found) > 0 {
+		found = map[string]string{}
+		n, err = t.getWithParams(string(key), found)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for name, v := range found {
+		params[name] = v
+	}
+
+	_, value = n.entry("")
+	return value, nil
+}
+
+// LongestMatchBytes is like LongestMatch, but takes the key as a byte slice and
+// returns the match as a slice of the key without allocating. In trees with case
+// folding the match is a copy of the key as it was registered.
+func (t *Tree) LongestMatchBytes(key []byte) (match []byte, value interface{}, err error) {
+	s := bytesView(key)
+	l, n, err := t.longestMatch(t.foldKey(s))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if f, ok := n.value.(*folded); ok {
+		return []byte(f.key), f.value, nil
+	}
+
+	return key[:l], n.value, nil
+}
+
+// DeleteBytes is like Delete, but takes the key as a byte slice without allocating
+func (t *Tree) DeleteBytes(key []byte) (err error) {
+	return t.Delete(bytesView(key))
+}
+
+// IterBytes is like Iter, but calls f with the keys in a byte slice reused across calls,
+// which must not be modified nor retained after f returns.
+// If f returns false, IterBytes stops the iteration.
+func (t *Tree) IterBytes(f func(key []byte, value interface{}) bool) {
+	it := &bytesIter{f: f}
+	it.iter(t.root, make([]byte, 0, 64))
+}
+
+// bytesIter holds the scratch buffer for registered keys in trees with case folding
+type bytesIter struct {
+	scratch []byte
+	f       func(key []byte, value interface{}) bool
+}
+
+func (it *bytesIter) iter(n *node, buf []byte) (ok bool) {
+	buf = append(buf, n.key...)
+
+	switch v := n.value.(type) {
+	case nil:
+	case *folded:
+		it.scratch = append(it.scratch[:0], v.key...)
+		if !it.f(it.scratch, v.value) {
+			return false
+		}
+	default:
+		if !it.f(buf, v) {
+			return false
+		}
+	}
+
+	for x := 0; x < len(n.children); x++ {
+		if !it.iter(n.children[x], buf) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,116 @@
+package radixs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBytes(t *testing.T) {
+	assert := newAssert(t)
+	tr := New()
+
+	for k, v := range pairs {
+		err := tr.SetBytes([]byte(k), v)
+		assert(err == nil, "error setting key:", k, "err:", err)
+	}
+
+	buf := []byte("romanus")
+	value, err := tr.GetBytes(buf)
+	assert(err == nil && value == pairs["romanus"], "wrong value:", value, "err:", err)
+
+	// the tree must not retain the lookup key
+	copy(buf, "rubicon")
+	value, err = tr.GetBytes(buf)
+	assert(err == nil && value == pairs["rubicon"], "wrong value:", value, "err:", err)
+
+	buf = []byte("smarties")
+	match, value, err := tr.LongestMatchBytes(buf)
+	assert(err == nil && string(match) == "smart" && value == pairs["smart"], "wrong match:", string(match), value, "err:", err)
+
+	_, err = tr.GetBytes([]byte("smalle"))
+	assert(err == ErrKeyNotFound, "unexpected value, err:", err)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = tr.GetBytes(buf[:5])
+		_, _, _ = tr.LongestMatchBytes(buf)
+	})
+	assert(allocs == 0, "lookups should not allocate, got:", allocs)
+
+	var keys []string
+	tr.IterBytes(func(key []byte, value interface{}) bool {
+		assert(pairs[string(key)] == value, "key:", string(key), "wrong value:", value)
+		keys = append(keys, string(key))
+		return true
+	})
+
+	var expected []string
+	tr.Iter(func(key string, value interface{}) bool {
+		expected = append(expected, key)
+		return true
+	})
+	assert(equalKeys(keys, expected), "expected:", expected, "got:", keys)
+
+	err = tr.DeleteBytes([]byte("smart"))
+	assert(err == nil && tr.Size() == uint64(len(pairs)-1), "error deleting key, size:", tr.Size(), "err:", err)
+}
+
+func TestBytesWithParams(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithCaseFolding())
+
+	err := tr.SetWithParamsBytes([]byte("/Users/:user/repos/:repo"), "repo")
+	assert(err == nil, "error setting key, err:", err)
+
+	buf := []byte("/users/bob/repos/radixs")
+	params := map[string]string{}
+	value, err := tr.GetWithParamsBytes(buf, params)
+	assert(err == nil && value == "repo", "wrong value:", value, "err:", err)
+
+	// parameter values must not share the key memory
+	copy(buf, "/users/ann/repos/xxxxxx")
+	assert(params["user"] == "bob" && params["repo"] == "radixs", "parameters changed with key buffer:", params)
+
+	match, value, err := tr.LongestMatchBytes([]byte("/USERS/:USER/REPOS/:REPO/x"))
+	assert(err == nil && string(match) == "/Users/:user/repos/:repo" && value == "repo", "wrong match:", string(match), "err:", err)
+
+	_ = tr.Set("/Groups", "groups")
+	var keys []string
+	tr.IterBytes(func(key []byte, value interface{}) bool {
+		keys = append(keys, string(key))
+		return true
+	})
+	assert(equalKeys(keys, []string{"/Groups", "/Users/:user/repos/:repo"}), "wrong registered keys:", keys)
+}
+
+func TestBytesWithParamsNotFound(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithParamConstraints('|'))
+
+	err := tr.SetWithParams("/users/:id", "user")
+	assert(err == nil, "error setting key, err:", err)
+	err = tr.SetWithParams("/repos/:id|int", "repo")
+	assert(err == nil, "error setting key, err:", err)
+
+	buf := []byte("/users/bob/x")
+	params := map[string]string{}
+	_, err = tr.GetWithParamsBytes(buf, params)
+	assert(err == ErrKeyNotFound, "unexpected value, err:", err)
+	assert(len(params) == 0, "parameters written on failed lookup:", params)
+
+	buf = []byte("/repos/bob")
+	_, err = tr.GetWithParamsBytes(buf, params)
+	var pe *ParamError
+	assert(errors.As(err, &pe) && pe.Value == "bob", "expected parameter error, err:", err)
+	assert(len(params) == 0, "parameters written on failed lookup:", params)
+
+	// errors must not share the key memory
+	copy(buf, "/repos/XXX")
+	assert(pe.Value == "bob", "parameter error changed with key buffer:", pe.Value)
+
+	buf = []byte("/users/bob")
+	value, err := tr.GetWithParamsBytes(buf, params)
+	assert(err == nil && value == "user" && params["id"] == "bob", "wrong value:", value, params, "err:", err)
+
+	copy(buf, "/users/XXX")
+	assert(params["id"] == "bob", "parameters changed with key buffer:", params)
+}
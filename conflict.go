@@ -0,0 +1,104 @@
+package radixs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ConflictError is returned when a key conflicts with an existing key in the tree.
+// It wraps ErrConflictKey so it can be checked with errors.Is.
+type ConflictError struct {
+	Pattern  string // key being set
+	Existing string // existing key conflicting with Pattern
+	Offset   int    // byte offset in Pattern where the conflict occurs
+}
+
+// Error implements the error interface
+func (e *ConflictError) Error() (s string) {
+	return fmt.Sprintf("%s: %q conflicts with existing key %q at offset %d",
+		ErrConflictKey, e.Pattern, e.Existing, e.Offset)
+}
+
+// Unwrap returns ErrConflictKey
+func (e *ConflictError) Unwrap() (err error) {
+	return ErrConflictKey
+}
+
+// conflict walks the tree along the given key, checking if setting it would
+// conflict with existing keys. Keys conflict when they diverge:
+// - at a parameter or wildcard placeholder, as it would match the other key literals
+// - inside or right after a parameter name, other than at a delimiter, as the parameter
+// value would not have an unique terminating literal.
+// It returns the node holding the key if it already exists, so it can be updated without another walk.
+func (t *Tree) conflict(key string, value interface{}) (existing *node, err error) {
+	full := key
+	pattern := key
+	if f, ok := value.(*folded); ok {
+		pattern = f.key
+	}
+
+	n := t.root
+	for {
+		if n.key == key {
+			if f, ok := n.value.(*folded); ok && f.key != pattern {
+				return nil, &ConflictError{Pattern: pattern, Existing: f.key, Offset: longestPrefix(pattern, f.key)}
+			}
+			return n, nil
+		}
+
+		consumed := len(full) - len(key)
+		pi := longestPrefix(n.key, key)
+		prefix := full[:consumed+pi]
+
+		// key diverges from the current node key
+		if pi > 0 && len(n.key) > pi {
+			switch {
+			// key ends inside the current node key
+			case pi == len(key):
+				if t.inParam(prefix) && !t.isDelimiter(n.key[pi]) {
+					return nil, t.conflictError(pattern, full[:consumed], n, len(prefix))
+				}
+
+			case t.isParamByte(n.key[pi]) || t.isParamByte(key[pi]) || t.inParam(prefix):
+				return nil, t.conflictError(pattern, full[:consumed], n, len(prefix))
+			}
+
+			return nil, nil
+		}
+
+		key = key[pi:]
+		i := sort.Search(len(n.children), func(x int) bool {
+			return n.children[x].key[0] >= key[0]
+		})
+
+		// child shares a prefix with key, continue
+		if i < len(n.children) && n.children[i].key[0] == key[0] {
+			n = n.children[i]
+			continue
+		}
+
+		// key would be added as a new child of the current node
+		if t.inParam(prefix) && (len(n.children) > 0 || !t.isDelimiter(key[0])) {
+			return nil, t.conflictError(pattern, full[:consumed], n, len(prefix))
+		}
+
+		for x := 0; x < len(n.children); x++ {
+			if t.isParamByte(key[0]) || t.isParamByte(n.children[x].key[0]) {
+				return nil, t.conflictError(pattern, prefix, n.children[x], len(prefix))
+			}
+		}
+
+		return nil, nil
+	}
+}
+
+// conflictError builds a ConflictError using the first key stored under the given node
+func (t *Tree) conflictError(pattern, prefix string, n *node, offset int) (err error) {
+	e := &ConflictError{Pattern: pattern, Offset: offset}
+	n.iter(prefix, func(key string, _ interface{}) bool {
+		e.Existing = key
+		return false
+	})
+
+	return e
+}
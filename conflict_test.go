@@ -0,0 +1,73 @@
+package radixs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConflictError(t *testing.T) {
+	assert := newAssert(t)
+
+	tests := []struct {
+		name     string
+		existing []string
+		pattern  string
+		conflict string
+		offset   int
+	}{
+		{"literal and param", []string{"/users/:user"}, "/users/me", "/users/:user", 7},
+		{"param names", []string{"/users/:user/repos"}, "/users/:id", "/users/:user/repos", 8},
+		{"param sibling", []string{"/users/", "/users/:user"}, "/users/me", "/users/:user", 7},
+		{"literal sibling", []string{"/users/", "/users/me"}, "/users/:user", "/users/me", 7},
+		{"param name prefix", []string{"/users/:user"}, "/users/:us", "/users/:user", 10},
+		{"literal after param", []string{"/users/:user"}, "/users/:user.json", "/users/:user", 12},
+		{"no conflict", []string{"/users/:user", "/users"}, "/users/:user/repos", "", 0},
+	}
+
+	for _, tt := range tests {
+		tr := New(WithParams('/', ':'))
+		for _, key := range tt.existing {
+			err := tr.SetWithParams(key, key)
+			assert(err == nil, tt.name, "error setting key:", key, "err:", err)
+		}
+
+		checkErr := tr.CheckPattern(tt.pattern)
+		size := tr.Size()
+		err := tr.SetWithParams(tt.pattern, tt.pattern)
+		assert(errors.Is(checkErr, ErrConflictKey) == errors.Is(err, ErrConflictKey),
+			tt.name, "check pattern and set results differ, check:", checkErr, "set:", err)
+
+		if tt.conflict == "" {
+			assert(err == nil, tt.name, "unexpected error:", err)
+			continue
+		}
+
+		assert(tr.Size() == size, tt.name, "tree modified by conflicting key, size:", tr.Size())
+
+		var ce *ConflictError
+		assert(errors.As(err, &ce), tt.name, "expected conflict error, got:", err)
+		if ce != nil {
+			assert(ce.Pattern == tt.pattern, tt.name, "expected pattern:", tt.pattern, "got:", ce.Pattern)
+			assert(ce.Existing == tt.conflict, tt.name, "expected existing:", tt.conflict, "got:", ce.Existing)
+			assert(ce.Offset == tt.offset, tt.name, "expected offset:", tt.offset, "got:", ce.Offset)
+		}
+	}
+}
+
+func TestCheckPattern(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithOptionalSegments('?'))
+
+	err := tr.SetWithParams("/docs/:page", "page")
+	assert(err == nil, "error setting key, err:", err)
+
+	err = tr.CheckPattern("/docs//:page")
+	assert(errors.Is(err, ErrInvalidKey), "check invalid pattern, err:", err)
+
+	err = tr.CheckPattern("/docs/:name?")
+	assert(errors.Is(err, ErrConflictKey), "check conflicting optional pattern, err:", err)
+
+	err = tr.CheckPattern("/docs/:page/history")
+	assert(err == nil, "check valid pattern, err:", err)
+	assert(tr.Size() == 1, "check pattern modified the tree, size:", tr.Size())
+}
@@ -0,0 +1,203 @@
+package radixs
+
+import (
+	"container/heap"
+	"math"
+)
+
+// KeyCount is a key and its count as returned by TopK
+type KeyCount struct {
+	Key   string
+	Count int64
+}
+
+// WithCounters enables the counting mode, where Incr keeps int64 counts for keys and
+// every node tracks the maximum count in its subtree, so TopK can prune branches.
+func WithCounters() (opt OptFunc) {
+	return func(t *Tree) {
+		t.counting = true
+		t.maxes = map[*node]int64{}
+	}
+}
+
+// count returns the count held by v and if v is a count
+func count(v interface{}) (c int64, ok bool) {
+	if f, isFolded := v.(*folded); isFolded {
+		v = f.value
+	}
+
+	c, ok = v.(int64)
+	return c, ok
+}
+
+// Incr adds delta to the count for the given key in a single traversal,
+// creating the key with delta as count if it does not exist, and returns the new count.
+// It requires the tree to be created with WithCounters and returns
+// ErrConflictKey if the key holds a value other than an int64 count.
+func (t *Tree) Incr(key string, delta int64) (c int64, err error) {
+	if !t.counting {
+		return 0, ErrIncompatibleTree
+	}
+
+	if key == "" {
+		return 0, ErrEmptyKey
+	}
+
+	n := t.insert(t.foldKey(key))
+
+	// keys folding to the same value of an existing key conflict
+	if err = foldConflict(n, key); err != nil {
+		return 0, err
+	}
+
+	if n.value == nil {
+		n.value = t.leaf(key, delta)
+		t.size++
+		c = delta
+	} else {
+		var ok bool
+		if c, ok = count(n.value); !ok {
+			return 0, ErrConflictKey
+		}
+		c += delta
+
+		if f, isFolded := n.value.(*folded); isFolded {
+			f.value = c
+		} else {
+			n.value = c
+		}
+	}
+
+	t.updateMax(n)
+	return c, nil
+}
+
+// TopK returns up to k keys starting with the given prefix with the highest counts,
+// sorted by descending count and ascending key. It requires the tree to be created
+// with WithCounters and only visits the branches that may hold the highest counts.
+func (t *Tree) TopK(prefix string, k int) (counts []KeyCount, err error) {
+	if !t.counting {
+		return nil, ErrIncompatibleTree
+	}
+
+	n, ok := t.prefixNode(t.foldKey(prefix))
+	if !ok || k <= 0 {
+		return nil, nil
+	}
+
+	path := ""
+	if n.parent != nil {
+		path = n.parent.fullKey()
+	}
+
+	h := &countHeap{{key: path + n.key, n: n, count: t.subtreeMax(n)}}
+	for h.Len() > 0 && len(counts) < k {
+		item := heap.Pop(h).(countItem)
+
+		// counted key
+		if item.n == nil {
+			counts = append(counts, KeyCount{Key: item.key, Count: item.count})
+			continue
+		}
+
+		if c, ok := count(item.n.value); ok {
+			key, _ := item.n.entry(item.key)
+			heap.Push(h, countItem{key: key, count: c})
+		}
+
+		for x := 0; x < len(item.n.children); x++ {
+			child := item.n.children[x]
+			if max := t.subtreeMax(child); max != math.MinInt64 {
+				heap.Push(h, countItem{key: item.key + child.key, n: child, count: max})
+			}
+		}
+	}
+
+	return counts, nil
+}
+
+// updateMax updates the maximum count in the subtree of n and its ancestors
+// in trees with the counting mode, stopping when an ancestor maximum is not affected
+func (t *Tree) updateMax(n *node) {
+	if t.counting {
+		t.updateMaxes(n)
+	}
+}
+
+func (t *Tree) updateMaxes(n *node) {
+	for first := true; n != nil; n, first = n.parent, false {
+		max := int64(math.MinInt64)
+		if c, ok := count(n.value); ok {
+			max = c
+		}
+
+		for x := 0; x < len(n.children); x++ {
+			if c := t.subtreeMax(n.children[x]); c > max {
+				max = c
+			}
+		}
+
+		// ancestors only depend on the maximum of this subtree, but the
+		// given node may be new and not yet accounted by its parent
+		if t.subtreeMax(n) == max && !first {
+			return
+		}
+		t.setSubtreeMax(n, max)
+	}
+}
+
+// subtreeMax returns the maximum count in the subtree of n, or math.MinInt64 if it has no counts
+func (t *Tree) subtreeMax(n *node) (max int64) {
+	if max, ok := t.maxes[n]; ok {
+		return max
+	}
+
+	return math.MinInt64
+}
+
+// setSubtreeMax sets the maximum count in the subtree of n. Only subtrees
+// with counts are tracked, so the nodes of other trees take no extra memory.
+func (t *Tree) setSubtreeMax(n *node, max int64) {
+	if max == math.MinInt64 {
+		delete(t.maxes, n)
+		return
+	}
+
+	t.maxes[n] = max
+}
+
+// countItem is either a node, ranked by the maximum count in its
+// subtree, or a counted key when n is nil
+type countItem struct {
+	key   string
+	n     *node
+	count int64
+}
+
+// countHeap is a max heap of count items by count and ascending key
+type countHeap []countItem
+
+func (h countHeap) Len() int { return len(h) }
+
+func (h countHeap) Less(i, j int) bool {
+	if h[i].count != h[j].count {
+		return h[i].count > h[j].count
+	}
+
+	// keys are visited before the nodes they prefix
+	if h[i].key != h[j].key {
+		return h[i].key < h[j].key
+	}
+	return h[i].n == nil
+}
+
+func (h countHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *countHeap) Push(x interface{}) { *h = append(*h, x.(countItem)) }
+
+func (h *countHeap) Pop() (x interface{}) {
+	old := *h
+	x = old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
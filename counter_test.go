@@ -0,0 +1,145 @@
+package radixs
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestCounter(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithCounters())
+
+	queries := map[string]int64{
+		"radix":       5,
+		"radix tree":  9,
+		"radix sort":  3,
+		"rad":         1,
+		"random":      7,
+		"tree":        12,
+		"trie":        2,
+		"radixs tree": 4,
+	}
+
+	for q, c := range queries {
+		for x := int64(0); x < c; x++ {
+			_, err := tr.Incr(q, 1)
+			assert(err == nil, "error incrementing:", q, "err:", err)
+		}
+	}
+
+	value, err := tr.Get("radix tree")
+	assert(err == nil && value == int64(9), "wrong count:", value, "err:", err)
+
+	c, err := tr.Incr("radix sort", -2)
+	assert(err == nil && c == 1, "wrong count after decrement:", c, "err:", err)
+	queries["radix sort"] = 1
+
+	top, err := tr.TopK("ra", 3)
+	assert(err == nil, "error getting top k, err:", err)
+	expected := []KeyCount{{"radix tree", 9}, {"random", 7}, {"radix", 5}}
+	assert(len(top) == len(expected), "expected:", expected, "got:", top)
+	for x := 0; x < len(top) && x < len(expected); x++ {
+		assert(top[x] == expected[x], "expected:", expected, "got:", top)
+	}
+
+	top, _ = tr.TopK("", 1)
+	assert(len(top) == 1 && top[0] == KeyCount{"tree", 12}, "wrong top key:", top)
+
+	top, _ = tr.TopK("xyz", 3)
+	assert(len(top) == 0, "unexpected keys for missing prefix:", top)
+
+	_ = tr.Delete("tree")
+	top, _ = tr.TopK("", 2)
+	assert(len(top) == 2 && top[0] == KeyCount{"radix tree", 9} && top[1] == KeyCount{"random", 7}, "wrong top keys after delete:", top)
+
+	_ = tr.Set("tree", "tree")
+	_, err = tr.Incr("tree", 1)
+	assert(err == ErrConflictKey, "incremented key with non count value, err:", err)
+
+	_, err = New().Incr("tree", 1)
+	assert(err == ErrIncompatibleTree, "incremented key without counting mode, err:", err)
+}
+
+func TestCounterTopKRandom(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithCounters(), WithCaseFolding())
+	counts := map[string]int64{}
+	r := rand.New(rand.NewSource(1))
+
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for x := 0; x < 2000; x++ {
+		key := keys[r.Intn(len(keys))]
+		switch r.Intn(10) {
+		case 0:
+			if tr.Delete(key) == nil {
+				delete(counts, key)
+			}
+		default:
+			delta := int64(r.Intn(20) - 5)
+			c, err := tr.Incr(key, delta)
+			assert(err == nil && c == counts[key]+delta, "key:", key, "expected:", counts[key]+delta, "got:", c, "err:", err)
+			counts[key] = c
+		}
+	}
+
+	for _, prefix := range []string{"", "r", "ro", "rom", "s", "sma", "x"} {
+		var expected []KeyCount
+		for k, c := range counts {
+			if strings.HasPrefix(k, prefix) {
+				expected = append(expected, KeyCount{k, c})
+			}
+		}
+		sort.Slice(expected, func(i, j int) bool {
+			if expected[i].Count != expected[j].Count {
+				return expected[i].Count > expected[j].Count
+			}
+			return expected[i].Key < expected[j].Key
+		})
+		if len(expected) > 5 {
+			expected = expected[:5]
+		}
+
+		top, err := tr.TopK(strings.ToUpper(prefix), 5)
+		assert(err == nil && len(top) == len(expected), "prefix:", prefix, "expected:", expected, "got:", top, "err:", err)
+		for x := 0; x < len(top) && x < len(expected); x++ {
+			assert(top[x] == expected[x], "prefix:", prefix, "expected:", expected, "got:", top)
+		}
+	}
+	// maximum counts are only kept for reachable nodes with counts in their subtree
+	tracked := 0
+	tr.root.dfsI(func(n *node) bool {
+		if _, ok := tr.maxes[n]; ok {
+			tracked++
+		}
+		return true
+	})
+	assert(tracked == len(tr.maxes), "stale maximum counts, tracked:", tracked, "stored:", len(tr.maxes))
+
+	_ = tr.DeletePrefix("r")
+	for k := range counts {
+		_ = tr.Delete(k)
+	}
+	assert(tr.Size() == 0 && len(tr.maxes) == 0, "maximum counts left after deleting all keys:", len(tr.maxes))
+}
+
+func TestCounterNodeSize(t *testing.T) {
+	assert := newAssert(t)
+	assert(unsafe.Sizeof(node{}) == 64, "counting mode must not grow nodes, size:", unsafe.Sizeof(node{}))
+
+	tr := New(WithCounters())
+	_, _ = tr.Incr("smart", 3)
+	_ = tr.Add("smarties", 1)
+	_ = tr.Add("sma", 1)
+
+	top, err := tr.TopK("sm", 3)
+	assert(err == nil && len(top) == 1 && top[0].Key == "smart", "wrong top keys:", top, "err:", err)
+	assert(len(tr.maxes) == 3, "only the root, sma and rt subtrees hold counts:", tr.maxes)
+}
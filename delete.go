@@ -33,43 +33,47 @@ func (t *Tree) delete(key string, prefix bool) (err error) {
 
 		// delete prefix remaining key segment is a prefix of next node
 		if prefix && len(key) == longestPrefix(key, n.children[i].key) {
-			var subSize int
+			var subSize, subPostings int
 			n.children[i].dfsI(func(n *node) bool {
 				if n.value != nil {
 					subSize++
+					subPostings += postingsLen(n.value)
 				}
+				delete(t.maxes, n)
 				return true
 			})
 
 			n.children = append(n.children[:i], n.children[i+1:]...)
+			t.merge(n)
+			t.updateMax(n)
 
 			t.size -= uint64(subSize)
+			t.postings -= uint64(subPostings)
 			return nil
 		}
 
 		// key found
 		if n.children[i].key == key {
+			// prefix nodes without value are not keys
+			if n.children[i].value == nil {
+				return ErrKeyNotFound
+			}
+
 			// checks if the current node is also
 			// a prefix for underlying child nodes and:
 			// - set the node value to nil if node is a prefix
 			// - remove the node if it has no underlying children
+			t.postings -= uint64(postingsLen(n.children[i].value))
 			switch len(n.children[i].children) > 0 {
 			case true:
 				n.children[i].value = nil
+				t.merge(n.children[i])
+				t.updateMax(n.children[i])
 			case false:
+				delete(t.maxes, n.children[i])
 				n.children = append(n.children[:i], n.children[i+1:]...)
-			}
-
-			// if the node has single child left, merge with its parent
-			if len(n.children) == 1 && n != t.root {
-				n.key += n.children[0].key
-				n.value = n.children[0].value
-				n.children = n.children[0].children
-
-				// update all children parent node
-				for x := 0; x < len(n.children); x++ {
-					n.children[x].parent = n
-				}
+				t.merge(n)
+				t.updateMax(n)
 			}
 
 			t.size--
@@ -81,3 +85,26 @@ func (t *Tree) delete(key string, prefix bool) (err error) {
 		n = n.children[i]
 	}
 }
+
+// merge merges a node without a value with its single child,
+// keeping the tree compressed after removals
+func (t *Tree) merge(n *node) {
+	if n == t.root || n.value != nil || len(n.children) != 1 {
+		return
+	}
+
+	child := n.children[0]
+	n.key += child.key
+	n.value = child.value
+	n.children = child.children
+
+	if t.counting {
+		t.setSubtreeMax(n, t.subtreeMax(child))
+		delete(t.maxes, child)
+	}
+
+	// update all children parent node
+	for x := 0; x < len(n.children); x++ {
+		n.children[x].parent = n
+	}
+}
@@ -85,3 +85,39 @@ func TestDeletePrefix(t *testing.T) {
 	assert(err == ErrKeyNotFound, "failed to get existing key:", key, "err:", err)
 	assert(value != pairs[key], "wrong value:", value, "for key:", key)
 }
+
+func TestRegressionDeleteMergeKeepsPrefixValue(t *testing.T) {
+	assert := newAssert(t)
+	tr := New()
+	_ = tr.Set("ab", 1)
+	_ = tr.Set("abc", 2)
+	_ = tr.Set("abd", 3)
+
+	err := tr.Delete("abd")
+	assert(err == nil, "failed to delete existing key: abd, err:", err)
+
+	value, err := tr.Get("ab")
+	assert(err == nil && value == 1, "prefix key lost after merge, value:", value, "err:", err)
+
+	value, err = tr.Get("abc")
+	assert(err == nil && value == 2, "sibling key lost after merge, value:", value, "err:", err)
+
+	_ = tr.Set("abcd", 4)
+	err = tr.Delete("abc")
+	assert(err == nil, "failed to delete existing key: abc, err:", err)
+
+	value, err = tr.Get("abcd")
+	assert(err == nil && value == 4, "child key lost after merge, value:", value, "err:", err)
+	assert(tr.root.children[0].key == "ab" && tr.root.children[0].children[0].key == "cd",
+		"deleted prefix not merged with its single child:", tr.String())
+}
+
+func TestRegressionDeletePrefixNode(t *testing.T) {
+	assert := newAssert(t)
+	tr, err := FromMap(pairs)
+	assert(err == nil, "error creating tree from map", "err:", err)
+
+	err = tr.Delete("rub")
+	assert(err == ErrKeyNotFound, "deleted prefix node without value, err:", err)
+	assert(tr.Size() == uint64(len(pairs)), "wrong size after deleting prefix node:", tr.Size())
+}
@@ -0,0 +1,232 @@
+package radixs
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+type caseFolding uint8
+
+const (
+	foldNone caseFolding = iota
+	foldASCII
+	foldUnicode
+)
+
+// folded holds the value and the original key as registered
+// in trees with case folding, where node keys are stored folded
+type folded struct {
+	key   string
+	value interface{}
+}
+
+// WithCaseFolding makes Set, Get, LongestMatch, GetWithParams and Delete
+// compare keys using ASCII case folding while preserving the registered key.
+// Setting keys that fold to the same value of an existing key returns ErrConflictKey.
+func WithCaseFolding() (opt OptFunc) {
+	return func(t *Tree) {
+		t.folding = foldASCII
+	}
+}
+
+// WithUnicodeCaseFolding is like WithCaseFolding but uses Unicode simple case folding
+func WithUnicodeCaseFolding() (opt OptFunc) {
+	return func(t *Tree) {
+		t.folding = foldUnicode
+	}
+}
+
+// foldKey returns the folded key according to the tree case folding.
+// It does not allocate if the key is already folded.
+func (t *Tree) foldKey(key string) (f string) {
+	if t.folding == foldNone {
+		return key
+	}
+
+	return t.fold(key)
+}
+
+// fold is kept out of foldKey so trees without case folding pay no call
+func (t *Tree) fold(key string) (f string) {
+	switch t.folding {
+	case foldASCII:
+		return foldASCIIString(key)
+	case foldUnicode:
+		return foldUnicodeString(key)
+	default:
+		return key
+	}
+}
+
+// leaf returns the value to be stored for the given key and value
+func (t *Tree) leaf(key string, value interface{}) (v interface{}) {
+	if t.folding == foldNone || value == nil {
+		return value
+	}
+
+	return &folded{key: key, value: value}
+}
+
+// originalRange maps the byte range [from, to) of the folded key back to the original key
+func (t *Tree) originalRange(key string, from, to int) (s string) {
+	if t.folding != foldUnicode {
+		return key[from:to]
+	}
+
+	var pos, start int
+	for x := 0; x < len(key); {
+		if pos == from {
+			start = x
+		}
+
+		if pos == to {
+			return key[start:x]
+		}
+
+		r, size := utf8.DecodeRuneInString(key[x:])
+		if r == utf8.RuneError && size == 1 {
+			pos++
+		} else {
+			pos += utf8.RuneLen(foldRune(r))
+		}
+
+		x += size
+	}
+
+	if pos == from {
+		start = len(key)
+	}
+
+	return key[start:]
+}
+
+// declaredParams renames the parameters matched for n to their names as declared
+// in the registered key, as node keys hold folded names in trees with case folding
+func (t *Tree) declaredParams(n *node, params map[string]string) {
+	f, ok := n.value.(*folded)
+	if !ok {
+		return
+	}
+
+	_ = t.parsePattern(f.key, func(s Segment) {
+		if s.Kind == SegmentStatic {
+			return
+		}
+
+		name := t.foldKey(s.Value)
+		if value, ok := params[name]; ok && name != s.Value {
+			delete(params, name)
+			params[s.Value] = value
+		}
+	})
+}
+
+// declaredName returns the name as declared in the registered keys under n
+// of the parameter with the given folded name, or name if not found
+func (t *Tree) declaredName(n *node, name string) (declared string) {
+	// non root nodes without value have children
+	for n.value == nil && len(n.children) > 0 {
+		n = n.children[0]
+	}
+
+	declared = name
+	if f, ok := n.value.(*folded); ok {
+		_ = t.parsePattern(f.key, func(s Segment) {
+			if s.Kind != SegmentStatic && t.foldKey(s.Value) == name {
+				declared = s.Value
+			}
+		})
+	}
+
+	return declared
+}
+
+// foldConflict returns a *ConflictError if n holds a key registered
+// differently from key which folds to the same value, or nil otherwise
+func foldConflict(n *node, key string) (err error) {
+	f, ok := n.value.(*folded)
+	if !ok || f.key == key {
+		return nil
+	}
+
+	return &ConflictError{Pattern: key, Existing: f.key, Offset: longestPrefix(key, f.key)}
+}
+
+// entry returns the original key and value stored in the node
+func (n *node) entry(key string) (k string, value interface{}) {
+	if f, ok := n.value.(*folded); ok {
+		return f.key, f.value
+	}
+
+	return key, n.value
+}
+
+func foldASCIIString(s string) (f string) {
+	for x := 0; x < len(s); x++ {
+		if 'A' <= s[x] && s[x] <= 'Z' {
+			b := []byte(s)
+			for ; x < len(b); x++ {
+				if 'A' <= b[x] && b[x] <= 'Z' {
+					b[x] += 'a' - 'A'
+				}
+			}
+
+			return string(b)
+		}
+	}
+
+	return s
+}
+
+func foldUnicodeString(s string) (f string) {
+	for x := 0; x < len(s); {
+		r, size := utf8.DecodeRuneInString(s[x:])
+		if (r != utf8.RuneError || size > 1) && foldRune(r) != r {
+			b := make([]byte, x, len(s)+utf8.UTFMax)
+			copy(b, s[:x])
+
+			for ; x < len(s); x += size {
+				r, size = utf8.DecodeRuneInString(s[x:])
+				if r == utf8.RuneError && size == 1 {
+					b = append(b, s[x])
+					continue
+				}
+				var buf [utf8.UTFMax]byte
+				b = append(b, buf[:utf8.EncodeRune(buf[:], foldRune(r))]...)
+			}
+
+			return string(b)
+		}
+
+		x += size
+	}
+
+	return s
+}
+
+// foldRune returns the representative rune for the simple case folding orbit of r,
+// its lower case form if part of the orbit or the smallest rune in the orbit otherwise
+func foldRune(r rune) (f rune) {
+	if r < utf8.RuneSelf {
+		if 'A' <= r && r <= 'Z' {
+			r += 'a' - 'A'
+		}
+		return r
+	}
+
+	f = r
+	for o := unicode.SimpleFold(r); o != r; o = unicode.SimpleFold(o) {
+		if o < f {
+			f = o
+		}
+	}
+
+	lower := unicode.ToLower(f)
+	for o := unicode.SimpleFold(f); o != f; o = unicode.SimpleFold(o) {
+		if o == lower {
+			return lower
+		}
+	}
+
+	return f
+}
@@ -0,0 +1,124 @@
+package radixs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCaseFolding(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithCaseFolding())
+
+	_ = tr.Set("Example.COM", 1)
+	_ = tr.Set("Content-Type", 2)
+	_ = tr.Set("content-length", 3)
+
+	tests := []struct {
+		key       string
+		canonical string
+		value     interface{}
+	}{
+		{"example.com", "Example.COM", 1},
+		{"EXAMPLE.COM", "Example.COM", 1},
+		{"content-type", "Content-Type", 2},
+		{"CONTENT-LENGTH", "content-length", 3},
+	}
+
+	for _, tt := range tests {
+		canonical, value, err := tr.GetCanonical(tt.key)
+		assert(err == nil, "key:", tt.key, "not found, err:", err)
+		assert(canonical == tt.canonical, "key:", tt.key, "expected canonical:", tt.canonical, "got:", canonical)
+		assert(value == tt.value, "key:", tt.key, "expected value:", tt.value, "got:", value)
+	}
+
+	match, value, err := tr.LongestMatch("CONTENT-TYPE; charset=utf-8")
+	assert(err == nil && match == "Content-Type" && value == 2, "longest match, got:", match, value, "err:", err)
+
+	err = tr.Set("EXAMPLE.com", 4)
+	assert(errors.Is(err, ErrConflictKey), "set key folding to an existing key, err:", err)
+
+	err = tr.Set("Example.COM", 5)
+	assert(err == nil, "update existing key, err:", err)
+
+	var keys []string
+	tr.Iter(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert(len(keys) == 3 && keys[0] == "content-length" && keys[1] == "Content-Type" && keys[2] == "Example.COM",
+		"iteration should report the original keys:", keys)
+
+	err = tr.Delete("example.com")
+	assert(err == nil && tr.Size() == 2, "delete folded key, size:", tr.Size(), "err:", err)
+}
+
+func TestUnicodeCaseFolding(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithUnicodeCaseFolding())
+
+	_ = tr.Set("Straße", 1)
+	_ = tr.Set("ΣΊΣΥΦΟΣ", 2)
+	_ = tr.Set("Kelvin", 3) // KELVIN SIGN
+
+	tests := []struct {
+		key       string
+		canonical string
+		value     interface{}
+	}{
+		{"STRAßE", "Straße", 1},
+		{"σίσυφος", "ΣΊΣΥΦΟΣ", 2},
+		{"σίσυφοσ", "ΣΊΣΥΦΟΣ", 2},
+		{"kelvin", "Kelvin", 3},
+		{"KELVIN", "Kelvin", 3},
+	}
+
+	for _, tt := range tests {
+		canonical, value, err := tr.GetCanonical(tt.key)
+		assert(err == nil, "key:", tt.key, "not found, err:", err)
+		assert(canonical == tt.canonical, "key:", tt.key, "expected canonical:", tt.canonical, "got:", canonical)
+		assert(value == tt.value, "key:", tt.key, "expected value:", tt.value, "got:", value)
+	}
+
+	_, err := tr.Get("strasse")
+	assert(err == ErrKeyNotFound, "simple folding should not expand ß, err:", err)
+
+	err = tr.Set("Kelvin", 4)
+	assert(errors.Is(err, ErrConflictKey), "set key folding to an existing key, err:", err)
+}
+
+func TestCaseFoldingWithParams(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithUnicodeCaseFolding())
+
+	err := tr.SetWithParams("/Users/:user/Repos/:repo", "repo")
+	assert(err == nil, "error setting key, err:", err)
+
+	params := map[string]string{}
+	value, err := tr.GetWithParams("/USERS/KiKi/repos/RadixS", params)
+	assert(err == nil && value == "repo", "get with params, value:", value, "err:", err)
+	assert(params["user"] == "KiKi" && params["repo"] == "RadixS", "parameter values should keep their case:", params)
+}
+
+func TestCaseFoldingParamNames(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithCaseFolding(), WithParamConstraints('|'))
+
+	err := tr.SetWithParams("/Users/:userID/repos/:repoName", "repo")
+	assert(err == nil, "error setting key, err:", err)
+	err = tr.SetWithParams("/Orgs/:orgID|int", "org")
+	assert(err == nil, "error setting key, err:", err)
+
+	params := map[string]string{}
+	value, err := tr.GetWithParams("/users/Bob/REPOS/RadixS", params)
+	assert(err == nil && value == "repo", "get with params, value:", value, "err:", err)
+	assert(len(params) == 2 && params["userID"] == "Bob" && params["repoName"] == "RadixS",
+		"parameters should keep their declared names:", params)
+
+	routes := tr.Routes()
+	assert(routes[1].Params[0] == "userID", "wrong route params:", routes[1].Params)
+
+	params = map[string]string{}
+	_, err = tr.GetWithParams("/orgs/acme", params)
+	var pe *ParamError
+	assert(errors.As(err, &pe) && pe.Name == "orgID" && pe.Value == "acme", "wrong parameter error:", err)
+}
@@ -0,0 +1,89 @@
+package radixs
+
+// FuzzyMatch calls f for each key within maxDist Levenshtein distance, in bytes, of the given key,
+// in ascending lexicographic order. Subtrees whose keys cannot be within maxDist are skipped.
+// If f returns false, FuzzyMatch stops the iteration.
+func (t *Tree) FuzzyMatch(key string, maxDist int, f func(key string, value interface{}, dist int) bool) {
+	if maxDist < 0 {
+		return
+	}
+
+	fm := &fuzzyMatcher{key: t.foldKey(key), max: maxDist, f: f}
+
+	// distance from the empty prefix to each key prefix
+	row := fm.row(0)
+	for x := 0; x < len(row); x++ {
+		row[x] = x
+	}
+
+	fm.match(t.root, "", 0)
+}
+
+// fuzzyMatcher holds the Levenshtein distance rows for each
+// byte of the path walked from the root, reused across subtrees
+type fuzzyMatcher struct {
+	key  string
+	max  int
+	f    func(key string, value interface{}, dist int) bool
+	rows [][]int
+}
+
+// row returns the distance row for the given path depth
+func (fm *fuzzyMatcher) row(depth int) (row []int) {
+	for len(fm.rows) <= depth {
+		fm.rows = append(fm.rows, make([]int, len(fm.key)+1))
+	}
+	return fm.rows[depth]
+}
+
+// match walks the subtree of n whose path from the root, excluding n.key, has the given depth
+func (fm *fuzzyMatcher) match(n *node, prefix string, depth int) (ok bool) {
+	for x := 0; x < len(n.key); x++ {
+		prev := fm.row(depth + x)
+		row := fm.row(depth + x + 1)
+		row[0] = prev[0] + 1
+
+		min := row[0]
+		for y := 1; y < len(row); y++ {
+			cost := 1
+			if fm.key[y-1] == n.key[x] {
+				cost = 0
+			}
+
+			row[y] = prev[y-1] + cost
+			if d := prev[y] + 1; d < row[y] {
+				row[y] = d
+			}
+			if d := row[y-1] + 1; d < row[y] {
+				row[y] = d
+			}
+
+			if row[y] < min {
+				min = row[y]
+			}
+		}
+
+		// no key under this path can be within the maximum distance
+		if min > fm.max {
+			return true
+		}
+	}
+
+	prefix += n.key
+	depth += len(n.key)
+
+	if dist := fm.row(depth)[len(fm.key)]; n.value != nil && dist <= fm.max {
+		key, value := n.entry(prefix)
+		if !fm.f(key, value, dist) {
+			return false
+		}
+	}
+
+	for x := 0; x < len(n.children); x++ {
+		if !fm.match(n.children[x], prefix, depth) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+package radixs
+
+import (
+	"testing"
+)
+
+// levenshtein returns the edit distance in bytes between a and b
+func levenshtein(a, b string) (d int) {
+	prev := make([]int, len(b)+1)
+	row := make([]int, len(b)+1)
+	for y := range prev {
+		prev[y] = y
+	}
+
+	for x := 1; x <= len(a); x++ {
+		row[0] = x
+		for y := 1; y <= len(b); y++ {
+			cost := 1
+			if a[x-1] == b[y-1] {
+				cost = 0
+			}
+			row[y] = prev[y-1] + cost
+			if prev[y]+1 < row[y] {
+				row[y] = prev[y] + 1
+			}
+			if row[y-1]+1 < row[y] {
+				row[y] = row[y-1] + 1
+			}
+		}
+		prev, row = row, prev
+	}
+
+	return prev[len(b)]
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	assert := newAssert(t)
+	tr, err := FromMap(pairs)
+	assert(err == nil, "error creating tree from map", "err:", err)
+
+	for _, key := range []string{"romane", "rubicn", "smallish", "x", "rom", "ruber"} {
+		for dist := 0; dist <= 3; dist++ {
+			expected := map[string]int{}
+			for k := range pairs {
+				if d := levenshtein(key, k); d <= dist {
+					expected[k] = d
+				}
+			}
+
+			var last string
+			found := map[string]int{}
+			tr.FuzzyMatch(key, dist, func(k string, value interface{}, d int) bool {
+				assert(k > last, "keys not in ascending order:", last, k)
+				assert(value == pairs[k], "key:", k, "wrong value:", value)
+				last = k
+				found[k] = d
+				return true
+			})
+
+			assert(len(found) == len(expected), "key:", key, "dist:", dist, "expected:", expected, "got:", found)
+			for k, d := range expected {
+				assert(found[k] == d, "key:", key, "dist:", dist, "match:", k, "expected distance:", d, "got:", found[k])
+			}
+		}
+	}
+
+	var count int
+	tr.FuzzyMatch("rom", 5, func(k string, value interface{}, d int) bool {
+		count++
+		return count < 2
+	})
+	assert(count == 2, "iteration not stopped, count:", count)
+
+	fr := New(WithCaseFolding())
+	_ = fr.Set("Romanus", 1)
+	count = 0
+	fr.FuzzyMatch("ROMANES", 1, func(k string, value interface{}, d int) bool {
+		assert(k == "Romanus" && d == 1, "unexpected match:", k, d)
+		count++
+		return true
+	})
+	assert(count == 1, "expected 1 case folded match, got:", count)
+}
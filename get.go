@@ -7,16 +7,55 @@ import (
 
 // GetWithParams is like Get but extracts path parameters and stores them
 // into the provided params argument which will be accessible after GetWithParams returns.
+// A parameter value ends at the next delimiter or at the literal byte following
+// the parameter name in the pattern, e.g. /img/:name.:ext matches /img/logo.png.
 func (t *Tree) GetWithParams(key string, params map[string]string) (value interface{}, err error) {
+	n, err := t.getWithParams(key, params)
+	if err != nil {
+		return nil, err
+	}
+
+	_, value = n.entry("")
+	return value, nil
+}
+
+// Match is like GetWithParams but also returns the matched key pattern as it was set,
+// e.g. /users/:user for /users/bob.
+func (t *Tree) Match(key string, params map[string]string) (pattern string, value interface{}, err error) {
+	n, err := t.getWithParams(key, params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	pattern, value = n.entry(n.fullKey())
+	return pattern, value, nil
+}
+
+func (t *Tree) getWithParams(key string, params map[string]string) (n *node, err error) {
+	n, err = t.matchParams(key, params)
+	if err == ErrKeyNotFound && t.trailingDelimiter {
+		if key, ok := t.toggleTrailingDelimiter(key); ok {
+			return t.matchParams(key, params)
+		}
+	}
+
+	return n, err
+}
+
+func (t *Tree) matchParams(key string, params map[string]string) (n *node, err error) {
 	if key == "" {
 		return nil, ErrEmptyKey
 	}
 
-	n := t.root
+	original := key
+	key = t.foldKey(key)
+	size := len(key)
+
+	n = t.root
 	for {
 		// binary search for prefix
 		i := sort.Search(len(n.children), func(x int) bool {
-			if n.children[x].key[0] == t.parameter {
+			if t.isParamByte(n.children[x].key[0]) {
 				return true
 			}
 
@@ -33,20 +72,31 @@ func (t *Tree) GetWithParams(key string, params map[string]string) (value interf
 		key = key[pi:]
 
 		// parameter found, start consuming until last parameter or end of key/nodeKey
-		for len(key) > 0 && len(nodeKey) > 0 && nodeKey[0] == t.parameter {
-			name := nodeKey[1:]
-			if pdIdx := strings.IndexByte(name, t.delimiter); pdIdx > -1 {
-				name = name[:pdIdx]
+		for len(key) > 0 && len(nodeKey) > 0 && t.isParamByte(nodeKey[0]) {
+			name, kind, rest, wildcard, reason := t.paramToken(nodeKey)
+			if reason != "" {
+				return nil, ErrKeyNotFound
 			}
 
+			// wildcards consume the remaining of the key
 			value := key
-			if vdIdx := strings.IndexByte(value, t.delimiter); vdIdx > -1 {
-				value = value[:vdIdx]
+			if !wildcard {
+				value = t.paramValue(key, rest)
 			}
 
-			params[name] = value
+			nodeKey = rest
+			params[name] = t.originalRange(original, size-len(key), size-len(key)+len(value))
 			key = key[len(value):]
-			nodeKey = nodeKey[len(name)+1:] // include the parameter placeholder
+
+			if kind != "" && !checkParam(kind, params[name]) {
+				e := &ParamError{Name: name, Value: params[name], Type: kind}
+				if t.folding != foldNone {
+					delete(params, name)
+					e.Name = t.declaredName(n.children[i], name)
+					params[e.Name] = e.Value
+				}
+				return nil, e
+			}
 
 			if pi := longestPrefix(nodeKey, key); pi > 0 {
 				key = key[pi:]
@@ -55,20 +105,17 @@ func (t *Tree) GetWithParams(key string, params map[string]string) (value interf
 		}
 
 		if key == "" {
-			if nodeKey == "" {
-				return n.children[i].value, nil
+			if nodeKey == "" && n.children[i].value != nil {
+				t.declaredParams(n.children[i], params)
+				return n.children[i], nil
 			}
 
 			return nil, ErrKeyNotFound
 		}
 
-		// exact match found
-		if n.children[i].key == key {
-			if n.children[i].value == nil {
-				return nil, ErrKeyNotFound
-			}
-
-			return n.children[i].value, nil
+		// remaining node key does not match the search key
+		if nodeKey != "" {
+			return nil, ErrKeyNotFound
 		}
 
 		// child is a prefix of the search key, continue
@@ -79,18 +126,73 @@ func (t *Tree) GetWithParams(key string, params map[string]string) (value interf
 // Get retrieves the value for the given key.
 // It returns false if the key was not found.
 func (t *Tree) Get(key string) (value interface{}, err error) {
+	n, err := t.lookup(key)
+	if err == ErrKeyNotFound && t.trailingDelimiter {
+		n, _, err = t.lookupToggled(key)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	_, value = n.entry("")
+	return value, nil
+}
+
+// GetCanonical is like Get, but also returns the key as it was registered,
+// which differs from the given key in trees with case folding.
+func (t *Tree) GetCanonical(key string) (canonical string, value interface{}, err error) {
+	n, err := t.lookup(key)
+	if err == ErrKeyNotFound && t.trailingDelimiter {
+		n, key, err = t.lookupToggled(key)
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	canonical, value = n.entry(key)
+	return canonical, value, nil
+}
+
+// lookupToggled retries the lookup with the trailing delimiter of key toggled
+// and returns the key the node was found with
+func (t *Tree) lookupToggled(key string) (n *node, matched string, err error) {
+	toggled, ok := t.toggleTrailingDelimiter(key)
+	if !ok {
+		return nil, key, ErrKeyNotFound
+	}
+
+	n, err = t.lookup(toggled)
+	return n, toggled, err
+}
+
+func (t *Tree) getValue(key string) (canonical string, value interface{}, err error) {
+	n, err := t.lookup(key)
+	if err != nil {
+		return "", nil, err
+	}
+
+	canonical, value = n.entry(key)
+	return canonical, value, nil
+}
+
+// lookup returns the node holding the given key
+func (t *Tree) lookup(key string) (n *node, err error) {
 	if key == "" {
 		return nil, ErrEmptyKey
 	}
 
-	n := t.root
+	key = t.foldKey(key)
+
+	n = t.root
 	for {
 		switch {
 		case key == n.key:
 			if n.value == nil {
 				return nil, ErrKeyNotFound
 			}
-			return n.value, nil
+			return n, nil
 
 		case strings.HasPrefix(key, n.key):
 			key = key[len(n.key):]
@@ -111,93 +213,78 @@ func (t *Tree) Get(key string) (value interface{}, err error) {
 
 // LongestMatch is like Get, but instead of an
 // exact match, it will return the longest prefix match.
+// In trees with case folding the match is the key as it was registered.
+// The match is a substring of the given key and does not allocate.
 func (t *Tree) LongestMatch(key string) (match string, value interface{}, err error) {
-	match, n, err := t.longestMatch(key)
+	l, n, err := t.longestMatch(t.foldKey(key))
 	if err != nil {
 		return "", nil, err
 	}
 
-	return match, n.value, nil
+	match, value = n.entry(t.originalRange(key, 0, l))
+	return match, value, nil
 }
 
 // NeighborMatch is like LongestMatch, but returns the longest match and surrounding keys:
 // parent, match, siblings, children and stores them into the provided matches map.
+// Use Neighbors to keep the relationship of each key to the match.
 func (t *Tree) NeighborMatch(key string, matches map[string]interface{}) (err error) {
-	match, n, err := t.longestMatch(key)
+	nb, err := t.Neighbors(key)
 	if err != nil {
 		return err
 	}
 
-	// add current node
-	matches[match] = n.value
-
-	// add current node children
-	for x := 0; x < len(n.children); x++ {
-		if n.children[x].value != nil {
-			matches[match+n.children[x].key] = n.children[x].value
-		}
+	matches[nb.Match.Key] = nb.Match.Value
+	if nb.Parent != nil {
+		matches[nb.Parent.Key] = nb.Parent.Value
 	}
 
-	// add current node parent
-	pKey := match[:len(n.parent.key)]
-	if n.parent.key != "" && n.parent.value != nil {
-		matches[pKey] = n.parent.value
+	for x := 0; x < len(nb.Siblings); x++ {
+		matches[nb.Siblings[x].Key] = nb.Siblings[x].Value
 	}
 
-	// add current node siblings
-	if len(n.parent.children) > 1 {
-		for x := 0; x < len(n.parent.children); x++ {
-			if n.parent.children[x].key != n.key && n.parent.children[x].value != nil {
-				matches[pKey+n.parent.children[x].key] = n.parent.children[x].value
-			}
-		}
+	for x := 0; x < len(nb.Children); x++ {
+		matches[nb.Children[x].Key] = nb.Children[x].Value
 	}
 
 	return nil
 }
 
-func (t *Tree) longestMatch(key string) (prefix string, n *node, err error) {
+// longestMatch returns the deepest node with a value along the key
+// path and the length of the key prefix it holds
+func (t *Tree) longestMatch(key string) (l int, n *node, err error) {
 	if key == "" {
-		return "", nil, ErrEmptyKey
+		return 0, nil, ErrEmptyKey
 	}
 
-	n = t.root
-	for {
-		prefix += n.key
+	var depth int
+	for c := t.root; ; {
+		if c.value != nil {
+			n, l = c, depth
+		}
 
-		if key == n.key {
-			if n.value != nil {
-				return prefix, n, nil
-			}
+		if depth == len(key) {
 			break
 		}
 
-		if strings.HasPrefix(key, n.key) {
-			key = key[len(n.key):]
-
-			i := sort.Search(len(n.children), func(x int) bool {
-				return n.children[x].key[0] >= key[0]
-			})
+		i := sort.Search(len(c.children), func(x int) bool {
+			return c.children[x].key[0] >= key[depth]
+		})
 
-			if i >= len(n.children) {
-				break
-			}
-			n = n.children[i]
-			continue
+		// no child matching the remaining of the key
+		if i >= len(c.children) || !strings.HasPrefix(key[depth:], c.children[i].key) {
+			break
 		}
 
-		break
+		c = c.children[i]
+		depth += len(c.key)
 	}
 
-	for n.parent != nil {
-		prefix = prefix[:len(prefix)-len(n.key)]
-		n = n.parent
-		if n.value != nil {
-			return prefix, n, nil
-		}
+	if n == nil {
+		return 0, nil, ErrKeyNotFound
 	}
 
-	return "", nil, ErrKeyNotFound
+	return l, n, nil
 }
 
 func (t *Tree) get(key string) (n *node, match bool) {
@@ -224,3 +311,15 @@ func (t *Tree) get(key string) (n *node, match bool) {
 		}
 	}
 }
+
+// toggleTrailingDelimiter removes the trailing delimiter from key if present, or adds it otherwise
+func (t *Tree) toggleTrailingDelimiter(key string) (toggled string, ok bool) {
+	switch {
+	case t.delimiters == "" || (len(key) < 2 && (key == "" || t.isDelimiter(key[0]))):
+		return "", false
+	case t.isDelimiter(key[len(key)-1]):
+		return key[:len(key)-1], true
+	default:
+		return key + t.delimiters[:1], true
+	}
+}
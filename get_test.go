@@ -23,6 +23,11 @@ func TestGetLongestMatch(t *testing.T) {
 
 	_, _, err = tr.LongestMatch("smallest")
 	assert(err != nil, "longest match for key:", key, "should not exist")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _, _ = tr.LongestMatch("smarties")
+	})
+	assert(allocs == 0, "longest match should not allocate, got:", allocs)
 }
 
 func TestGetWithParams(t *testing.T) {
@@ -215,3 +220,183 @@ func TestRegressionGetMultipleParamsSameKey(t *testing.T) {
 	_, err = tr.GetWithParams("urn:documents:accounts:E7B4320A06A1", params)
 	assert(err != nil, "expected key not found:", err, "params", params)
 }
+
+func TestGetWithParamsMidSegment(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'))
+
+	keys := []string{
+		"/img/:name.:ext",
+		"/v:major.:minor/docs/:page",
+		"/files/:name.tar.gz",
+		"/files/:name.zip",
+	}
+
+	for _, key := range keys {
+		err := tr.SetWithParams(key, key)
+		assert(err == nil, "error setting key:", key, "error:", err)
+	}
+
+	tests := []struct {
+		key    string
+		value  interface{}
+		params map[string]string
+	}{
+		{"/img/logo.png", "/img/:name.:ext", map[string]string{"name": "logo", "ext": "png"}},
+		{"/img/logo.tar.gz", "/img/:name.:ext", map[string]string{"name": "logo", "ext": "tar.gz"}},
+		{"/v1.2/docs/intro", "/v:major.:minor/docs/:page", map[string]string{"major": "1", "minor": "2", "page": "intro"}},
+		{"/files/backup.tar.gz", "/files/:name.tar.gz", map[string]string{"name": "backup"}},
+		{"/files/backup.zip", "/files/:name.zip", map[string]string{"name": "backup"}},
+		{"/img/logo", nil, nil},
+		{"/v1/docs/intro", nil, nil},
+		{"/files/backup.rar", nil, nil},
+	}
+
+	for _, tt := range tests {
+		params := map[string]string{}
+		value, err := tr.GetWithParams(tt.key, params)
+
+		if tt.value == nil {
+			assert(err == ErrKeyNotFound, "key:", tt.key, "should not exist, value:", value, "params:", params)
+			continue
+		}
+
+		assert(err == nil, "failed to get key:", tt.key, "error:", err)
+		assert(value == tt.value, "wrong value for key:", tt.key, "got:", value, "expected:", tt.value)
+		assert(len(params) == len(tt.params), "invalid parameters for key:", tt.key, "params:", params)
+		for k, v := range tt.params {
+			assert(params[k] == v, "invalid parameter:", k, "for key:", tt.key, "expected:", v, "got:", params[k])
+		}
+	}
+}
+
+func TestGetTrailingDelimiterTolerance(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithTrailingDelimiterTolerance())
+
+	_ = tr.SetWithParams("/users", "users")
+	_ = tr.SetWithParams("/users/:user/", "user")
+	_ = tr.SetWithParams("/", "root")
+
+	tests := []struct {
+		key   string
+		value interface{}
+	}{
+		{"/users", "users"},
+		{"/users/", "users"},
+		{"/users/bob", "user"},
+		{"/users/bob/", "user"},
+		{"/", "root"},
+		{"/accounts/", nil},
+	}
+
+	for _, tt := range tests {
+		params := map[string]string{}
+		value, err := tr.GetWithParams(tt.key, params)
+		if tt.value == nil {
+			assert(err == ErrKeyNotFound, "key:", tt.key, "should not exist, value:", value)
+			continue
+		}
+		assert(err == nil && value == tt.value, "key:", tt.key, "expected:", tt.value, "got:", value, "err:", err)
+	}
+
+	value, err := tr.Get("/users/")
+	assert(err == nil && value == "users", "get with trailing delimiter, got:", value, "err:", err)
+
+	tr = New(WithParams('/', ':'))
+	_ = tr.SetWithParams("/users", "users")
+	_, err = tr.Get("/users/")
+	assert(err == ErrKeyNotFound, "trailing delimiter should not be tolerated by default, err:", err)
+
+	// without delimiters there is no trailing delimiter to tolerate
+	tr = New(WithTrailingDelimiterTolerance())
+	_ = tr.Set("users", "users")
+	_, err = tr.Get("accounts")
+	assert(err == ErrKeyNotFound, "missing key without delimiters, err:", err)
+	_, err = tr.GetWithParams("accounts", map[string]string{})
+	assert(err == ErrKeyNotFound, "missing key without delimiters, err:", err)
+}
+
+func TestRegressionLongestMatchLeafPrefix(t *testing.T) {
+	assert := newAssert(t)
+	tr, err := FromMap(pairs)
+	assert(err == nil, "error creating tree from map", "err:", err)
+
+	match, value, err := tr.LongestMatch("rubberizedly")
+	assert(err == nil && match == "rubberized" && value == pairs["rubberized"],
+		"longest match: invalid match", match, "value:", value, "err:", err)
+}
+
+func TestMatch(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithWildcard('*'), WithTrailingDelimiterTolerance())
+
+	keys := []string{
+		"/users/:user",
+		"/users/:user/repos/:repo",
+		"/files/*path",
+		"/health",
+	}
+
+	for _, key := range keys {
+		err := tr.SetWithParams(key, key+" value")
+		assert(err == nil, "error setting key:", key, "error:", err)
+	}
+
+	tests := []struct {
+		key     string
+		pattern string
+		params  map[string]string
+	}{
+		{"/users/bob", "/users/:user", map[string]string{"user": "bob"}},
+		{"/users/bob/", "/users/:user", map[string]string{"user": "bob"}},
+		{"/users/bob/repos/radixs", "/users/:user/repos/:repo", map[string]string{"user": "bob", "repo": "radixs"}},
+		{"/files/docs/intro.md", "/files/*path", map[string]string{"path": "docs/intro.md"}},
+		{"/health", "/health", map[string]string{}},
+	}
+
+	for _, tt := range tests {
+		params := map[string]string{}
+		pattern, value, err := tr.Match(tt.key, params)
+		assert(err == nil, "failed to match key:", tt.key, "error:", err)
+		assert(pattern == tt.pattern, "key:", tt.key, "expected pattern:", tt.pattern, "got:", pattern)
+		assert(value == tt.pattern+" value", "key:", tt.key, "wrong value:", value)
+		assert(len(params) == len(tt.params), "key:", tt.key, "invalid params:", params)
+		for k, v := range tt.params {
+			assert(params[k] == v, "key:", tt.key, "invalid parameter:", k, "expected:", v, "got:", params[k])
+		}
+	}
+
+	_, _, err := tr.Match("/files/", map[string]string{})
+	assert(err == ErrKeyNotFound, "wildcard should not match empty values, err:", err)
+}
+
+func TestRegressionGetWithParamsPrefixKeys(t *testing.T) {
+	assert := newAssert(t)
+
+	tests := [][]string{
+		{"/v1", "/v1/v1"},
+		{"/ab", "/a/a"},
+		{"/users/:id", "/users/:id/users/:id2"},
+	}
+
+	for _, keys := range tests {
+		tr := New(WithParams('/', ':'))
+		for _, key := range keys {
+			err := tr.SetWithParams(key, key)
+			assert(err == nil, "error setting key:", key, "error:", err)
+		}
+
+		for _, key := range keys {
+			value, err := tr.GetWithParams(key, map[string]string{})
+			assert(err == nil && value == key, "key:", key, "wrong value:", value, "err:", err)
+		}
+	}
+
+	tr := New(WithParams('/', ':'))
+	_ = tr.SetWithParams("/users/:id", "user")
+	_ = tr.SetWithParams("/users/:id/users", "users")
+	params := map[string]string{}
+	value, err := tr.GetWithParams("/users/1/users", params)
+	assert(err == nil && value == "users" && params["id"] == "1", "wrong value:", value, "params:", params, "err:", err)
+}
@@ -0,0 +1,266 @@
+package radixs
+
+import (
+	"path"
+	"sort"
+)
+
+type globKind uint8
+
+const (
+	globLiteral globKind = iota // single literal byte
+	globAny                     // ? matching any single non delimiter byte
+	globStar                    // * matching any sequence of non delimiter bytes
+	globClass                   // [...] matching a single byte in or not in the class ranges
+)
+
+// globToken is a single compiled glob pattern element
+type globToken struct {
+	kind   globKind
+	c      byte
+	negate bool
+	ranges []byte // pairs of inclusive low and high bytes
+}
+
+// globMatcher holds a compiled glob pattern and walks the tree
+// carrying the set of pattern positions matching the walked path
+type globMatcher struct {
+	t      *Tree
+	tokens []globToken
+	f      func(key string, value interface{}) bool
+}
+
+// WithGlobCrossDelimiters makes * in Glob patterns match delimiters too,
+// so user:* matches user:1:session with ':' as delimiter.
+func WithGlobCrossDelimiters() (opt OptFunc) {
+	return func(t *Tree) {
+		t.globCross = true
+	}
+}
+
+// Glob calls f for each key matching the shell pattern in ascending lexicographic order.
+// The pattern syntax is the one of path.Match applied to bytes, with the tree delimiters,
+// if any, as separators: * matches any sequence of non delimiter bytes, ? matches a single
+// non delimiter byte, [a-z] and [^a-z] match a single byte in or not in the class and
+// \ escapes the following byte. Literal parts of the pattern are searched using the
+// sorted node children, only branching on wildcards and classes.
+// It returns path.ErrBadPattern if the pattern is malformed.
+// If f returns false, Glob stops the iteration.
+func (t *Tree) Glob(pattern string, f func(key string, value interface{}) bool) (err error) {
+	tokens, err := compileGlob(t.foldKey(pattern))
+	if err != nil {
+		return err
+	}
+
+	g := &globMatcher{t: t, tokens: tokens, f: f}
+	g.match(t.root, "", g.closure([]int{0}))
+	return nil
+}
+
+// compileGlob compiles the glob pattern into tokens
+func compileGlob(pattern string) (tokens []globToken, err error) {
+	for x := 0; x < len(pattern); x++ {
+		switch pattern[x] {
+		case '*':
+			// consecutive stars are equivalent to a single one
+			if len(tokens) == 0 || tokens[len(tokens)-1].kind != globStar {
+				tokens = append(tokens, globToken{kind: globStar})
+			}
+
+		case '?':
+			tokens = append(tokens, globToken{kind: globAny})
+
+		case '\\':
+			if x++; x >= len(pattern) {
+				return nil, path.ErrBadPattern
+			}
+			tokens = append(tokens, globToken{kind: globLiteral, c: pattern[x]})
+
+		case '[':
+			tok := globToken{kind: globClass}
+			if x++; x < len(pattern) && pattern[x] == '^' {
+				tok.negate = true
+				x++
+			}
+
+			for ; x < len(pattern) && (pattern[x] != ']' || len(tok.ranges) == 0); x++ {
+				lo, l, ok := classByte(pattern[x:])
+				if !ok {
+					return nil, path.ErrBadPattern
+				}
+				x += l
+
+				hi := lo
+				if x+1 < len(pattern) && pattern[x+1] == '-' {
+					if hi, l, ok = classByte(pattern[x+2:]); !ok {
+						return nil, path.ErrBadPattern
+					}
+					x += l + 2
+				}
+
+				tok.ranges = append(tok.ranges, lo, hi)
+			}
+
+			if x >= len(pattern) {
+				return nil, path.ErrBadPattern
+			}
+			tokens = append(tokens, tok)
+
+		default:
+			tokens = append(tokens, globToken{kind: globLiteral, c: pattern[x]})
+		}
+	}
+
+	return tokens, nil
+}
+
+// classByte returns the possibly escaped byte at the start of a class
+// and the number of bytes it takes beyond the first one
+func classByte(s string) (c byte, l int, ok bool) {
+	switch {
+	case s == "" || s[0] == ']' || s[0] == '-':
+		return 0, 0, false
+	case s[0] == '\\':
+		if len(s) < 2 {
+			return 0, 0, false
+		}
+		return s[1], 1, true
+	default:
+		return s[0], 0, true
+	}
+}
+
+// matches checks if the token matches the byte c, without following stars
+func (g *globMatcher) matches(tok *globToken, c byte) (ok bool) {
+	switch tok.kind {
+	case globLiteral:
+		return tok.c == c
+	case globAny:
+		return !g.t.isDelimiter(c)
+	case globStar:
+		return g.t.globCross || !g.t.isDelimiter(c)
+	default:
+		for x := 0; x < len(tok.ranges); x += 2 {
+			if tok.ranges[x] <= c && c <= tok.ranges[x+1] {
+				return !tok.negate
+			}
+		}
+		return tok.negate
+	}
+}
+
+// closure adds the positions following stars, which may match empty sequences
+func (g *globMatcher) closure(states []int) (closed []int) {
+	for x := 0; x < len(states); x++ {
+		s := states[x]
+		if s < len(g.tokens) && g.tokens[s].kind == globStar && !containsState(states, s+1) {
+			states = append(states, s+1)
+		}
+	}
+
+	return states
+}
+
+// step returns the pattern positions reached from states after matching the byte c
+func (g *globMatcher) step(states []int, c byte) (next []int) {
+	for x := 0; x < len(states); x++ {
+		s := states[x]
+		if s >= len(g.tokens) || !g.matches(&g.tokens[s], c) {
+			continue
+		}
+
+		// stars stay on the same position after matching
+		if g.tokens[s].kind != globStar {
+			s++
+		}
+
+		if !containsState(next, s) {
+			next = append(next, s)
+		}
+	}
+
+	return g.closure(next)
+}
+
+// match walks the subtree of n, whose path from the root excluding n.key is prefix,
+// with the pattern positions matching prefix
+func (g *globMatcher) match(n *node, prefix string, states []int) (ok bool) {
+	for x := 0; x < len(n.key) && len(states) > 0; x++ {
+		states = g.step(states, n.key[x])
+	}
+
+	if len(states) == 0 {
+		return true
+	}
+
+	prefix += n.key
+	if n.value != nil && containsState(states, len(g.tokens)) {
+		if !g.f(n.entry(prefix)) {
+			return false
+		}
+	}
+
+	// only literals can follow, search the children
+	// starting with them instead of visiting all of them
+	if literal, ok := g.literals(states); ok {
+		for x := 0; x < len(literal); x++ {
+			i := sort.Search(len(n.children), func(y int) bool {
+				return n.children[y].key[0] >= literal[x]
+			})
+
+			if i < len(n.children) && n.children[i].key[0] == literal[x] {
+				if !g.match(n.children[i], prefix, states) {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	for x := 0; x < len(n.children); x++ {
+		if !g.match(n.children[x], prefix, states) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// literals returns the sorted bytes that can follow the given
+// pattern positions if all of them are literal bytes
+func (g *globMatcher) literals(states []int) (literal []byte, ok bool) {
+	for x := 0; x < len(states); x++ {
+		if states[x] >= len(g.tokens) {
+			continue
+		}
+
+		tok := &g.tokens[states[x]]
+		if tok.kind != globLiteral {
+			return nil, false
+		}
+		literal = append(literal, tok.c)
+	}
+
+	sort.Slice(literal, func(i, j int) bool { return literal[i] < literal[j] })
+
+	// remove duplicates so children are visited once
+	l := 0
+	for x := 0; x < len(literal); x++ {
+		if x == 0 || literal[x] != literal[x-1] {
+			literal[l] = literal[x]
+			l++
+		}
+	}
+
+	return literal[:l], true
+}
+
+func containsState(states []int, s int) (ok bool) {
+	for x := 0; x < len(states); x++ {
+		if states[x] == s {
+			return true
+		}
+	}
+	return false
+}
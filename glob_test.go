@@ -0,0 +1,141 @@
+package radixs
+
+import (
+	"math/rand"
+	"path"
+	"sort"
+	"testing"
+)
+
+func TestGlob(t *testing.T) {
+	assert := newAssert(t)
+	kv := copyMap(pairs)
+	for _, k := range []string{"user/1/session", "user/2/session", "user/2/profile", "user/10/session/old", "a*b", "a[b", "ab"} {
+		kv[k] = k
+	}
+
+	tr, err := FromMap(kv, WithDelimiters("/"))
+	assert(err == nil, "error creating tree from map", "err:", err)
+
+	patterns := []string{
+		"rub?c*", "rom*", "*", "*e", "r*n*", "user/*/session", "user/*", "user/?/session",
+		"user/[0-9]/*", "user/[^1]/*", "[rs]*[sr]", "a\\*b", "a[*]b", "a[\\[]b", "???", "*/*/*",
+		"romane", "x*", "", "ro[a-n]*us", "ab]", "[z-a]*",
+	}
+
+	for _, pattern := range patterns {
+		var expected []string
+		for k := range kv {
+			if ok, _ := path.Match(pattern, k); ok {
+				expected = append(expected, k)
+			}
+		}
+		sort.Strings(expected)
+
+		var found []string
+		err := tr.Glob(pattern, func(key string, value interface{}) bool {
+			assert(value == kv[key], "pattern:", pattern, "key:", key, "wrong value:", value)
+			found = append(found, key)
+			return true
+		})
+
+		assert(err == nil, "pattern:", pattern, "error:", err)
+		assert(len(found) == len(expected), "pattern:", pattern, "expected:", expected, "got:", found)
+		for x := 0; x < len(found) && x < len(expected); x++ {
+			assert(found[x] == expected[x], "pattern:", pattern, "expected:", expected, "got:", found)
+		}
+	}
+
+	for _, pattern := range []string{"[", "a[", "[]", "a\\", "[a-"} {
+		err := tr.Glob(pattern, func(key string, value interface{}) bool { return true })
+		assert(err == path.ErrBadPattern, "pattern:", pattern, "expected bad pattern, got:", err)
+	}
+
+	var count int
+	_ = tr.Glob("*", func(key string, value interface{}) bool {
+		count++
+		return count < 3
+	})
+	assert(count == 3, "iteration not stopped, count:", count)
+}
+
+func TestGlobRandomPatterns(t *testing.T) {
+	assert := newAssert(t)
+	rnd := rand.New(rand.NewSource(1))
+	alphabet := "ab/]-^\\*?["
+
+	random := func(n int) string {
+		b := make([]byte, 1+rnd.Intn(n))
+		for x := 0; x < len(b); x++ {
+			b[x] = alphabet[rnd.Intn(len(alphabet))]
+		}
+		return string(b)
+	}
+
+	tr := New(WithDelimiters("/"))
+	for x := 0; x < 500; x++ {
+		_ = tr.Set(random(6), x)
+	}
+
+	var keys []string
+	tr.Iter(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+
+	for x := 0; x < 5000; x++ {
+		pattern := random(6)
+
+		var expected []string
+		var expectedErr error
+		for _, k := range keys {
+			ok, err := path.Match(pattern, k)
+			if err != nil {
+				expectedErr = err
+				break
+			}
+			if ok {
+				expected = append(expected, k)
+			}
+		}
+
+		var found []string
+		err := tr.Glob(pattern, func(key string, value interface{}) bool {
+			found = append(found, key)
+			return true
+		})
+
+		assert(err == expectedErr, "pattern:", pattern, "expected error:", expectedErr, "got:", err)
+		if expectedErr == nil {
+			assert(equalKeys(found, expected), "pattern:", pattern, "expected:", expected, "got:", found)
+		}
+	}
+}
+
+func TestGlobCrossDelimiters(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams(':', '@'), WithGlobCrossDelimiters(), WithCaseFolding())
+
+	for _, k := range []string{"user:1:session", "user:1:profile", "User:2:Session", "user:2:a:session", "group:1:session"} {
+		_ = tr.Set(k, k)
+	}
+
+	var found []string
+	err := tr.Glob("user:*:session", func(key string, value interface{}) bool {
+		found = append(found, key)
+		return true
+	})
+
+	expected := []string{"user:1:session", "user:2:a:session", "User:2:Session"}
+	assert(err == nil && len(found) == len(expected), "expected:", expected, "got:", found, "err:", err)
+	for x := 0; x < len(found) && x < len(expected); x++ {
+		assert(found[x] == expected[x], "expected:", expected, "got:", found)
+	}
+
+	found = nil
+	_ = tr.Glob("user:?:*", func(key string, value interface{}) bool {
+		found = append(found, key)
+		return true
+	})
+	assert(len(found) == 4, "? must not match delimiters, got:", found)
+}
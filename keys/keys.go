@@ -0,0 +1,168 @@
+// Package keys provides order preserving encoders and decoders for using
+// integers, floats, timestamps and tuples as radixs.Tree keys, so that the
+// lexicographic order of the encoded keys is the natural order of their values.
+package keys
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidEncoding = fmt.Errorf("keys: invalid encoding")
+)
+
+const (
+	escape     = 0x00 // tuple escape byte
+	terminator = 0x01 // follows the escape byte at the end of a tuple element
+	escaped    = 0xff // follows the escape byte for literal escape bytes in a tuple element
+)
+
+// Uint64 encodes v as 8 big endian bytes
+func Uint64(v uint64) (key string) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return string(b[:])
+}
+
+// DecodeUint64 decodes a key encoded with Uint64
+func DecodeUint64(key string) (v uint64, err error) {
+	if len(key) != 8 {
+		return 0, ErrInvalidEncoding
+	}
+
+	return binary.BigEndian.Uint64([]byte(key)), nil
+}
+
+// Int64 encodes v as 8 big endian bytes with the sign bit flipped,
+// so negative numbers sort before positive ones
+func Int64(v int64) (key string) {
+	return Uint64(uint64(v) ^ (1 << 63))
+}
+
+// DecodeInt64 decodes a key encoded with Int64
+func DecodeInt64(key string) (v int64, err error) {
+	u, err := DecodeUint64(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(u ^ (1 << 63)), nil
+}
+
+// Float64 encodes v as 8 big endian bytes of its IEEE 754 representation, with the
+// sign bit flipped for positive numbers and all bits flipped for negative numbers.
+// Negative zero sorts before positive zero and NaNs sort after positive infinity,
+// or before negative infinity if their sign bit is set.
+func Float64(v float64) (key string) {
+	u := math.Float64bits(v)
+	if u&(1<<63) != 0 {
+		return Uint64(^u)
+	}
+
+	return Uint64(u | (1 << 63))
+}
+
+// DecodeFloat64 decodes a key encoded with Float64
+func DecodeFloat64(key string) (v float64, err error) {
+	u, err := DecodeUint64(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if u&(1<<63) != 0 {
+		return math.Float64frombits(u &^ (1 << 63)), nil
+	}
+
+	return math.Float64frombits(^u), nil
+}
+
+// Time encodes t as its Unix seconds encoded with Int64 followed by its
+// nanoseconds as 4 big endian bytes. The location is not encoded.
+func Time(t time.Time) (key string) {
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(t.Nanosecond()))
+	return Int64(t.Unix()) + string(b[:])
+}
+
+// DecodeTime decodes a key encoded with Time as an UTC time
+func DecodeTime(key string) (t time.Time, err error) {
+	if len(key) != 12 {
+		return time.Time{}, ErrInvalidEncoding
+	}
+
+	sec, err := DecodeInt64(key[:8])
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	nsec := binary.BigEndian.Uint32([]byte(key[8:]))
+	if nsec >= 1e9 {
+		return time.Time{}, ErrInvalidEncoding
+	}
+
+	return time.Unix(sec, int64(nsec)).UTC(), nil
+}
+
+// Tuple encodes the elements into a single key ordered by the first element, then
+// by the second and so on. Each element is followed by a 0x00 0x01 terminator and
+// 0x00 bytes within elements are escaped as 0x00 0xff. Encoded elements, as the ones
+// returned by Int64 or Time, can be used as elements. The tuple of a prefix of the
+// elements is a prefix of the key, allowing prefix iteration and LongestMatch by element.
+func Tuple(elements ...string) (key string) {
+	b := strings.Builder{}
+	for x := 0; x < len(elements); x++ {
+		e := elements[x]
+		for {
+			i := strings.IndexByte(e, escape)
+			if i == -1 {
+				break
+			}
+
+			b.WriteString(e[:i+1])
+			b.WriteByte(escaped)
+			e = e[i+1:]
+		}
+
+		b.WriteString(e)
+		b.WriteByte(escape)
+		b.WriteByte(terminator)
+	}
+
+	return b.String()
+}
+
+// SplitTuple decodes a key encoded with Tuple into its elements
+func SplitTuple(key string) (elements []string, err error) {
+	var b []byte
+	for x := 0; x < len(key); x++ {
+		if key[x] != escape {
+			b = append(b, key[x])
+			continue
+		}
+
+		if x++; x >= len(key) {
+			return nil, ErrInvalidEncoding
+		}
+
+		switch key[x] {
+		case escaped:
+			b = append(b, escape)
+		case terminator:
+			elements = append(elements, string(b))
+			b = b[:0]
+		default:
+			return nil, ErrInvalidEncoding
+		}
+	}
+
+	// unterminated element
+	if len(b) > 0 {
+		return nil, ErrInvalidEncoding
+	}
+
+	return elements, nil
+}
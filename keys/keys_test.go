@@ -0,0 +1,154 @@
+package keys
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/brunotm/radixs"
+)
+
+func newAssert(t testing.TB) func(cond bool, kvs ...interface{}) {
+	return func(cond bool, args ...interface{}) {
+		if !cond {
+			t.Helper()
+			t.Error(args...)
+		}
+	}
+}
+
+func TestInt64(t *testing.T) {
+	assert := newAssert(t)
+	values := []int64{math.MinInt64, -1 << 40, -256, -1, 0, 1, 255, 256, 1 << 40, math.MaxInt64}
+
+	for x := 0; x < len(values); x++ {
+		v, err := DecodeInt64(Int64(values[x]))
+		assert(err == nil && v == values[x], "expected:", values[x], "got:", v, "err:", err)
+
+		if x > 0 {
+			assert(Int64(values[x-1]) < Int64(values[x]), "order not preserved:", values[x-1], values[x])
+		}
+	}
+
+	_, err := DecodeInt64("short")
+	assert(err == ErrInvalidEncoding, "decoded invalid key, err:", err)
+}
+
+func TestUint64(t *testing.T) {
+	assert := newAssert(t)
+	values := []uint64{0, 1, 255, 256, 1 << 40, math.MaxUint64}
+
+	for x := 0; x < len(values); x++ {
+		v, err := DecodeUint64(Uint64(values[x]))
+		assert(err == nil && v == values[x], "expected:", values[x], "got:", v, "err:", err)
+
+		if x > 0 {
+			assert(Uint64(values[x-1]) < Uint64(values[x]), "order not preserved:", values[x-1], values[x])
+		}
+	}
+}
+
+func TestFloat64(t *testing.T) {
+	assert := newAssert(t)
+	values := []float64{math.Inf(-1), -math.MaxFloat64, -1.5, -1, -math.SmallestNonzeroFloat64, math.Copysign(0, -1),
+		0, math.SmallestNonzeroFloat64, 1, 1.5, math.MaxFloat64, math.Inf(1), math.NaN()}
+
+	for x := 0; x < len(values); x++ {
+		v, err := DecodeFloat64(Float64(values[x]))
+		assert(err == nil && math.Float64bits(v) == math.Float64bits(values[x]), "expected:", values[x], "got:", v, "err:", err)
+
+		if x > 0 {
+			assert(Float64(values[x-1]) < Float64(values[x]), "order not preserved:", values[x-1], values[x])
+		}
+	}
+}
+
+func TestTime(t *testing.T) {
+	assert := newAssert(t)
+	values := []time.Time{
+		time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC),
+		time.Unix(0, 0),
+		time.Unix(0, 1),
+		time.Date(2022, 2, 2, 10, 0, 0, 5, time.FixedZone("X", 3600)),
+		time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	for x := 0; x < len(values); x++ {
+		v, err := DecodeTime(Time(values[x]))
+		assert(err == nil && v.Equal(values[x]), "expected:", values[x], "got:", v, "err:", err)
+
+		if x > 0 {
+			assert(Time(values[x-1]) < Time(values[x]), "order not preserved:", values[x-1], values[x])
+		}
+	}
+
+	_, err := DecodeTime(Int64(0) + "\xff\xff\xff\xff")
+	assert(err == ErrInvalidEncoding, "decoded invalid nanoseconds, err:", err)
+}
+
+func TestTuple(t *testing.T) {
+	assert := newAssert(t)
+	tuples := [][]string{
+		{},
+		{""},
+		{"", "a"},
+		{"a"},
+		{"a", ""},
+		{"a", "b"},
+		{"a\x00"},
+		{"a\x00", "b"},
+		{"a\x00\x00b"},
+		{"a\x01"},
+		{"ab"},
+		{Int64(-1), "x"},
+	}
+
+	for x := 0; x < len(tuples); x++ {
+		elements, err := SplitTuple(Tuple(tuples[x]...))
+		assert(err == nil && len(elements) == len(tuples[x]), "expected:", tuples[x], "got:", elements, "err:", err)
+		for y := 0; y < len(elements) && y < len(tuples[x]); y++ {
+			assert(elements[y] == tuples[x][y], "expected:", tuples[x], "got:", elements)
+		}
+	}
+
+	for x := 1; x < len(tuples)-1; x++ {
+		assert(Tuple(tuples[x-1]...) < Tuple(tuples[x]...), "order not preserved:", tuples[x-1], tuples[x])
+	}
+
+	for _, key := range []string{"a", "a\x00", "a\x00\x02"} {
+		_, err := SplitTuple(key)
+		assert(err == ErrInvalidEncoding, "decoded invalid tuple:", key, "err:", err)
+	}
+}
+
+func TestTreeOrder(t *testing.T) {
+	assert := newAssert(t)
+	tr := radixs.New()
+	r := rand.New(rand.NewSource(1))
+
+	values := make([]int64, 1000)
+	for x := 0; x < len(values); x++ {
+		values[x] = r.Int63n(1<<20) - 1<<19
+		_ = tr.Set(Tuple("events", Int64(values[x])), values[x])
+	}
+	_ = tr.Set(Tuple("events"), "events")
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	var last int64 = math.MinInt64
+	tr.Iter(func(key string, value interface{}) bool {
+		elements, err := SplitTuple(key)
+		assert(err == nil && elements[0] == "events", "invalid key:", key, "err:", err)
+		if len(elements) == 2 {
+			v, _ := DecodeInt64(elements[1])
+			assert(v >= last && v == value, "keys not in order:", last, v)
+			last = v
+		}
+		return true
+	})
+
+	match, value, err := tr.LongestMatch(Tuple("events", Int64(1<<30), "details"))
+	assert(err == nil && match == Tuple("events") && value == "events", "wrong longest match:", match, value, "err:", err)
+}
@@ -0,0 +1,215 @@
+package radixs
+
+import (
+	"sort"
+	"strings"
+)
+
+// Mount splices the keys of the sub tree under the given prefix, without
+// copying or reinserting them. The sub tree nodes are moved and sub is left empty.
+// Both trees must have the same parameters, case folding and counting configuration and
+// no keys may exist under the prefix, otherwise a *ConflictError is returned.
+func (t *Tree) Mount(prefix string, sub *Tree) (err error) {
+	if prefix == "" {
+		return ErrEmptyKey
+	}
+
+	if sub == t || sub.syntax != t.syntax || sub.folding != t.folding || sub.counting != t.counting {
+		return ErrIncompatibleTree
+	}
+
+	if len(sub.root.children) == 0 {
+		return nil
+	}
+
+	foldedPrefix := t.foldKey(prefix)
+	if err = t.mountConflict(prefix, foldedPrefix, sub); err != nil {
+		return err
+	}
+
+	// registered keys of case folded leaves are full keys, prepend the prefix
+	if t.folding != foldNone {
+		sub.root.dfs(func(n *node) bool {
+			if f, ok := n.value.(*folded); ok {
+				f.key = prefix + f.key
+			}
+			return true
+		})
+	}
+
+	// maximum counts of the moved nodes
+	for c, max := range sub.maxes {
+		if c != sub.root {
+			t.maxes[c] = max
+		}
+	}
+
+	n := t.insert(foldedPrefix)
+	n.children = sub.root.children
+	for x := 0; x < len(n.children); x++ {
+		n.children[x].parent = n
+	}
+	t.merge(n)
+	t.updateMax(n)
+
+	t.size += sub.size
+	t.postings += sub.postings
+	sub.root = &node{}
+	sub.size = 0
+	sub.postings = 0
+	if sub.counting {
+		sub.maxes = map[*node]int64{}
+	}
+
+	return nil
+}
+
+// Unmount detaches all keys under the given prefix, excluding the prefix itself,
+// and returns them as a new tree with the same configuration and keys relative to the prefix.
+func (t *Tree) Unmount(prefix string) (sub *Tree, err error) {
+	if prefix == "" {
+		return nil, ErrEmptyKey
+	}
+
+	foldedPrefix := t.foldKey(prefix)
+	if _, ok := t.prefixNode(foldedPrefix); !ok {
+		return nil, ErrKeyNotFound
+	}
+
+	// split the path at the prefix boundary if needed
+	n := t.insert(foldedPrefix)
+	if len(n.children) == 0 {
+		return nil, ErrKeyNotFound
+	}
+
+	sub = &Tree{
+		syntax:            t.syntax,
+		root:              &node{children: n.children},
+		trailingDelimiter: t.trailingDelimiter,
+		folding:           t.folding,
+		counting:          t.counting,
+		globCross:         t.globCross,
+	}
+
+	if t.counting {
+		sub.maxes = map[*node]int64{}
+	}
+
+	for x := 0; x < len(n.children); x++ {
+		n.children[x].parent = sub.root
+	}
+
+	sub.root.dfs(func(n *node) bool {
+		if n.value != nil {
+			sub.size++
+			sub.postings += uint64(postingsLen(n.value))
+		}
+
+		// registered keys of case folded leaves are full keys, strip the prefix
+		if f, ok := n.value.(*folded); ok {
+			f.key = t.originalRange(f.key, len(foldedPrefix), len(t.foldKey(f.key)))
+		}
+
+		// maximum counts of the moved nodes
+		if max, ok := t.maxes[n]; ok {
+			sub.maxes[n] = max
+			delete(t.maxes, n)
+		}
+		return true
+	})
+
+	n.children = nil
+	t.size -= sub.size
+	t.postings -= sub.postings
+	t.remove(n)
+	t.updateMax(n)
+	sub.updateMax(sub.root)
+
+	return sub, nil
+}
+
+// mountConflict checks if mounting the sub tree under prefix conflicts with existing keys
+func (t *Tree) mountConflict(prefix, folded string, sub *Tree) (err error) {
+	// existing keys under prefix
+	if n, ok := t.prefixNode(folded); ok {
+		path := n.parent.fullKey()
+		if len(path)+len(n.key) > len(folded) {
+			return t.conflictError(prefix+sub.first(), path, n, len(prefix))
+		}
+
+		// the prefix itself may be a key, report the first key below it
+		if len(n.children) > 0 {
+			return t.conflictError(prefix+sub.first(), path+n.key, n.children[0], len(prefix))
+		}
+	}
+
+	if t.open == "" && t.wildcard == 0 {
+		return nil
+	}
+
+	// parameter conflicts along the prefix path
+	for x := 0; x < len(sub.root.children); x++ {
+		key := sub.root.children[x].key
+		if _, err = t.conflict(folded+key, t.leaf(prefix+key, struct{}{})); err != nil {
+			if ce, ok := err.(*ConflictError); ok {
+				ce.Pattern = prefix + sub.first()
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// prefixNode returns the node whose subtree holds all keys starting with the given key
+func (t *Tree) prefixNode(key string) (n *node, ok bool) {
+	n = t.root
+	for {
+		// key ends at or within the current node
+		if strings.HasPrefix(n.key, key) {
+			return n, true
+		}
+
+		if !strings.HasPrefix(key, n.key) {
+			return nil, false
+		}
+
+		key = key[len(n.key):]
+		i := sort.Search(len(n.children), func(x int) bool {
+			return n.children[x].key[0] >= key[0]
+		})
+
+		if i >= len(n.children) || n.children[i].key[0] != key[0] {
+			return nil, false
+		}
+		n = n.children[i]
+	}
+}
+
+// first returns the first key in the tree
+func (t *Tree) first() (key string) {
+	t.Iter(func(k string, _ interface{}) bool {
+		key = k
+		return false
+	})
+
+	return key
+}
+
+// remove removes a non root node without value nor children
+// from the tree and merges its parent if left with a single child
+func (t *Tree) remove(n *node) {
+	if n == t.root || n.value != nil || len(n.children) > 0 {
+		return
+	}
+
+	p := n.parent
+	for x := 0; x < len(p.children); x++ {
+		if p.children[x] == n {
+			p.children = append(p.children[:x], p.children[x+1:]...)
+			break
+		}
+	}
+
+	t.merge(p)
+}
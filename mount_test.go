@@ -0,0 +1,172 @@
+package radixs
+
+import (
+	"errors"
+	"testing"
+)
+
+func newBillingTree(t *testing.T) (sub *Tree) {
+	assert := newAssert(t)
+	sub = New(WithParams('/', ':'))
+
+	for _, key := range []string{"/invoices", "/invoices/:invoice", "/accounts/:account"} {
+		err := sub.SetWithParams(key, key)
+		assert(err == nil, "error setting key:", key, "err:", err)
+	}
+
+	return sub
+}
+
+func TestMount(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'))
+	_ = tr.SetWithParams("/api/v1/users/:user", "user")
+	_ = tr.SetWithParams("/api/v1/billing", "billing")
+
+	sub := newBillingTree(t)
+	err := tr.Mount("/api/v1/billing", sub)
+	assert(err == nil, "error mounting tree:", err)
+	assert(tr.Size() == 5, "wrong size after mount:", tr.Size())
+	assert(sub.Size() == 0 && len(sub.root.children) == 0, "sub tree not emptied after mount")
+
+	params := map[string]string{}
+	pattern, value, err := tr.Match("/api/v1/billing/invoices/42", params)
+	assert(err == nil && pattern == "/api/v1/billing/invoices/:invoice" && value == "/invoices/:invoice",
+		"wrong match after mount, pattern:", pattern, "value:", value, "err:", err)
+	assert(params["invoice"] == "42", "wrong params:", params)
+
+	value, err = tr.Get("/api/v1/billing")
+	assert(err == nil && value == "billing", "mount point key lost, value:", value, "err:", err)
+
+	tr.root.dfs(func(n *node) bool {
+		for _, c := range n.children {
+			assert(c.parent == n, "wrong parent for node:", c.key)
+		}
+		return true
+	})
+
+	// mounting into a new path compresses the mount point
+	tr = New(WithParams('/', ':'))
+	_ = tr.SetWithParams("/api/v1/users", "users")
+	sub = New(WithParams('/', ':'))
+	_ = sub.SetWithParams("/invoices", "invoices")
+	err = tr.Mount("/api/v1/billing", sub)
+	assert(err == nil, "error mounting tree:", err)
+	value, err = tr.Get("/api/v1/billing/invoices")
+	assert(err == nil && value == "invoices", "mounted key not found, value:", value, "err:", err)
+	assert(tr.root.children[0].children[0].key == "billing/invoices", "mount point not merged:", tr.String())
+}
+
+func TestMountConflicts(t *testing.T) {
+	assert := newAssert(t)
+
+	tr := New(WithParams('/', ':'))
+	_ = tr.SetWithParams("/api/v1/billing/invoices", "invoices")
+	err := tr.Mount("/api/v1/billing", newBillingTree(t))
+
+	var ce *ConflictError
+	assert(errors.As(err, &ce), "expected conflict with keys under prefix, got:", err)
+	if ce != nil {
+		assert(ce.Existing == "/api/v1/billing/invoices", "wrong existing key:", ce.Existing)
+		assert(ce.Pattern == "/api/v1/billing/accounts/:account", "wrong pattern:", ce.Pattern)
+	}
+	assert(tr.Size() == 1, "tree modified by conflicting mount, size:", tr.Size())
+
+	tr = New(WithParams('/', ':'))
+	_ = tr.SetWithParams("/api/v1/:service/health", "health")
+	err = tr.Mount("/api/v1/billing", newBillingTree(t))
+	assert(errors.Is(err, ErrConflictKey), "expected parameter conflict, got:", err)
+
+	tr = New(WithParams('/', ':'))
+	_ = tr.SetWithParams("/api/v1/users", "users")
+	_ = tr.SetWithParams("/api/v1/users/:id", "user")
+	err = tr.Mount("/api/v1/users", newBillingTree(t))
+	ce = nil
+	assert(errors.As(err, &ce), "expected conflict with keys under prefix, got:", err)
+	if ce != nil {
+		assert(ce.Existing == "/api/v1/users/:id", "wrong existing key:", ce.Existing)
+	}
+
+	tr = New(WithParams('/', ':'))
+	err = tr.Mount("/api", New())
+	assert(err == ErrIncompatibleTree, "expected incompatible tree, got:", err)
+}
+
+func TestUnmount(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'))
+	_ = tr.SetWithParams("/api/v1/users/:user", "user")
+
+	err := tr.Mount("/api/v1/billing", newBillingTree(t))
+	assert(err == nil, "error mounting tree:", err)
+
+	_, err = tr.Unmount("/api/v2")
+	assert(err == ErrKeyNotFound, "unmount missing prefix, err:", err)
+
+	sub, err := tr.Unmount("/api/v1/billing")
+	assert(err == nil, "error unmounting tree:", err)
+	assert(tr.Size() == 1 && sub.Size() == 3, "wrong sizes after unmount:", tr.Size(), sub.Size())
+
+	_, err = tr.Get("/api/v1/billing/invoices")
+	assert(err == ErrKeyNotFound, "unmounted key still present, err:", err)
+	assert(len(tr.root.children) == 1 && tr.root.children[0].key == "/api/v1/users/:user",
+		"tree not compressed after unmount:", tr.String())
+
+	params := map[string]string{}
+	value, err := sub.GetWithParams("/accounts/42", params)
+	assert(err == nil && value == "/accounts/:account" && params["account"] == "42",
+		"wrong value in unmounted tree:", value, "err:", err)
+
+	// unmount a prefix ending inside a node key
+	_ = tr.SetWithParams("/api/v1/users/:user/repos", "repos")
+	sub, err = tr.Unmount("/api/v1/users/:user/re")
+	assert(err == nil && sub.Size() == 1, "error unmounting partial prefix:", err)
+	value, err = sub.Get("pos")
+	assert(err == nil && value == "repos", "wrong unmounted key, value:", value, "err:", err)
+	assert(tr.Size() == 1, "wrong size after unmount:", tr.Size())
+}
+
+func TestMountCaseFolding(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithCaseFolding())
+	_ = tr.Set("/Health", "health")
+
+	sub := New(WithCaseFolding())
+	_ = sub.Set("/Users", "users")
+
+	err := tr.Mount("/API", sub)
+	assert(err == nil, "error mounting tree:", err)
+
+	keys := map[string]interface{}{}
+	tr.Iter(func(key string, value interface{}) bool {
+		keys[key] = value
+		return true
+	})
+	assert(len(keys) == 2 && keys["/API/Users"] == "users", "wrong registered keys after mount:", keys)
+
+	key, value, err := tr.GetCanonical("/api/users")
+	assert(err == nil && key == "/API/Users" && value == "users", "wrong canonical key:", key, "err:", err)
+
+	match, _, err := tr.LongestMatch("/api/users/x")
+	assert(err == nil && match == "/API/Users", "wrong longest match:", match, "err:", err)
+
+	sub, err = tr.Unmount("/api")
+	assert(err == nil && sub.Size() == 1, "error unmounting tree:", err)
+	sub.Iter(func(key string, value interface{}) bool {
+		assert(key == "/Users", "wrong registered key after unmount:", key)
+		return true
+	})
+
+	key, _, err = sub.GetCanonical("/USERS")
+	assert(err == nil && key == "/Users", "wrong canonical key:", key, "err:", err)
+
+	sub = New(WithUnicodeCaseFolding())
+	_ = sub.Set("/Ärger", "ärger")
+	tr = New(WithUnicodeCaseFolding())
+	_ = tr.Mount("/ÖL", sub)
+
+	sub, err = tr.Unmount("/öl")
+	assert(err == nil, "error unmounting tree:", err)
+	key, _, err = sub.GetCanonical("/ärger")
+	assert(err == nil && key == "/Ärger", "wrong canonical key:", key, "err:", err)
+}
@@ -0,0 +1,130 @@
+package radixs
+
+// PostingList holds the values added with Add for a single key in insertion order.
+// It is the value returned for those keys by Get, Iter and the other read methods
+// and reflects later calls to Add and RemoveValue for the key.
+type PostingList struct {
+	values []interface{}
+}
+
+// Len returns the number of values
+func (p *PostingList) Len() (l int) {
+	return len(p.values)
+}
+
+// At returns the value at index x, which must be less than Len
+func (p *PostingList) At(x int) (value interface{}) {
+	return p.values[x]
+}
+
+// Values returns a copy of the values
+func (p *PostingList) Values() (values []interface{}) {
+	return append([]interface{}{}, p.values...)
+}
+
+// postingsLen returns the number of values in v if it is a posting list
+func postingsLen(v interface{}) (l int) {
+	if f, ok := v.(*folded); ok {
+		v = f.value
+	}
+
+	if p, ok := v.(*PostingList); ok {
+		return len(p.values)
+	}
+
+	return 0
+}
+
+// Add appends the value to the posting list of the given key in a single traversal,
+// creating the key if it does not exist, like term to documents lists in inverted indexes.
+// Adding values to a key set with Set returns ErrConflictKey.
+func (t *Tree) Add(key string, value interface{}) (err error) {
+	if key == "" {
+		return ErrEmptyKey
+	}
+
+	if value == nil {
+		return ErrNilValue
+	}
+
+	n := t.insert(t.foldKey(key))
+	if n.value == nil {
+		n.value = t.leaf(key, &PostingList{values: []interface{}{value}})
+		t.size++
+		t.postings++
+		t.updateMax(n)
+		return nil
+	}
+
+	// keys folding to the same value of an existing key conflict
+	if err = foldConflict(n, key); err != nil {
+		return err
+	}
+
+	current := n.value
+	if f, ok := current.(*folded); ok {
+		current = f.value
+	}
+
+	p, ok := current.(*PostingList)
+	if !ok {
+		return ErrConflictKey
+	}
+
+	p.values = append(p.values, value)
+	t.postings++
+	return nil
+}
+
+// Values returns a copy of the posting list for the given key.
+// It returns ErrValueNotFound if the key was not set with Add.
+func (t *Tree) Values(key string) (values []interface{}, err error) {
+	_, value, err := t.getValue(key)
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := value.(*PostingList)
+	if !ok {
+		return nil, ErrValueNotFound
+	}
+
+	return p.Values(), nil
+}
+
+// RemoveValue removes the first occurrence of the value from the posting list of the given key.
+// Values are compared with ==, so they must be comparable. The key is removed
+// from the tree once its last value is removed.
+func (t *Tree) RemoveValue(key string, value interface{}) (err error) {
+	_, current, err := t.getValue(key)
+	if err != nil {
+		return err
+	}
+
+	p, ok := current.(*PostingList)
+	if !ok {
+		return ErrValueNotFound
+	}
+
+	for x := 0; x < len(p.values); x++ {
+		if p.values[x] != value {
+			continue
+		}
+
+		if len(p.values) == 1 {
+			return t.delete(t.foldKey(key), false)
+		}
+
+		p.values = append(p.values[:x], p.values[x+1:]...)
+		t.postings--
+		return nil
+	}
+
+	return ErrValueNotFound
+}
+
+// Postings returns the total number of values added with Add
+// in the tree, while Size returns the number of keys
+func (t *Tree) Postings() (sz uint64) {
+	return t.postings
+}
@@ -0,0 +1,101 @@
+package radixs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMultimap(t *testing.T) {
+	assert := newAssert(t)
+	tr := New()
+
+	index := map[string][]interface{}{
+		"radix":  {1, 2, 3},
+		"radixs": {2},
+		"tree":   {1, 3, 3},
+	}
+
+	for term, docs := range index {
+		for _, doc := range docs {
+			err := tr.Add(term, doc)
+			assert(err == nil, "error adding term:", term, "doc:", doc, "error:", err)
+		}
+	}
+
+	assert(tr.Size() == 3, "expected size 3, got:", tr.Size())
+	assert(tr.Postings() == 7, "expected 7 postings, got:", tr.Postings())
+
+	for term, docs := range index {
+		values, err := tr.Values(term)
+		assert(err == nil && len(values) == len(docs), "term:", term, "expected:", docs, "got:", values, "err:", err)
+		for x := 0; x < len(values) && x < len(docs); x++ {
+			assert(values[x] == docs[x], "term:", term, "expected:", docs, "got:", values)
+		}
+	}
+
+	// read methods return the posting list of the key
+	tr.Iter(func(key string, value interface{}) bool {
+		p, ok := value.(*PostingList)
+		assert(ok && p.Len() == len(index[key]) && p.At(0) == index[key][0], "key:", key, "wrong posting list:", value)
+		if ok {
+			values := p.Values()
+			values[0] = nil
+			assert(p.At(0) == index[key][0], "key:", key, "posting list modified through its values")
+		}
+		return true
+	})
+
+	err := tr.RemoveValue("tree", 3)
+	assert(err == nil, "error removing value, err:", err)
+	values, _ := tr.Values("tree")
+	assert(len(values) == 2 && values[0] == 1 && values[1] == 3, "wrong values after remove:", values)
+	assert(tr.Postings() == 6, "expected 6 postings, got:", tr.Postings())
+
+	err = tr.RemoveValue("tree", 4)
+	assert(err == ErrValueNotFound, "removed missing value, err:", err)
+
+	err = tr.RemoveValue("radixs", 2)
+	assert(err == nil, "error removing last value, err:", err)
+	_, err = tr.Values("radixs")
+	assert(err == ErrKeyNotFound, "key not removed after removing last value, err:", err)
+	assert(tr.Size() == 2 && tr.Postings() == 5, "wrong size:", tr.Size(), "postings:", tr.Postings())
+
+	_ = tr.Set("trie", "trie")
+	err = tr.Add("trie", 1)
+	assert(err == ErrConflictKey, "value added to key set with Set, err:", err)
+	_, err = tr.Values("trie")
+	assert(err == ErrValueNotFound, "values found for key set with Set, err:", err)
+
+	err = tr.Add("", 1)
+	assert(err == ErrEmptyKey, "empty key was added, err:", err)
+	err = tr.Add("tree", nil)
+	assert(err == ErrNilValue, "nil value was added, err:", err)
+
+	_ = tr.Set("tree", "tree")
+	assert(tr.Postings() == 3, "postings not updated after overwrite, got:", tr.Postings())
+
+	_ = tr.DeletePrefix("ra")
+	assert(tr.Postings() == 0 && tr.Size() == 2, "postings not updated after delete prefix, got:", tr.Postings(), "size:", tr.Size())
+}
+
+func TestMultimapMount(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithCaseFolding())
+	sub := New(WithCaseFolding())
+
+	_ = sub.Add("Radix", 1)
+	_ = sub.Add("Radix", 2)
+	_ = tr.Add("Tree", 1)
+
+	err := tr.Add("tree", 2)
+	assert(errors.Is(err, ErrConflictKey), "value added to key folding to existing key, err:", err)
+
+	err = tr.Mount("en/", sub)
+	assert(err == nil && tr.Postings() == 3 && sub.Postings() == 0, "wrong postings after mount:", tr.Postings(), sub.Postings(), "err:", err)
+
+	values, err := tr.Values("EN/RADIX")
+	assert(err == nil && len(values) == 2, "wrong values:", values, "err:", err)
+
+	sub, err = tr.Unmount("en/")
+	assert(err == nil && tr.Postings() == 1 && sub.Postings() == 2, "wrong postings after unmount:", tr.Postings(), sub.Postings(), "err:", err)
+}
@@ -0,0 +1,112 @@
+package radixs
+
+// Neighbor is a stored key and its value in a Neighborhood. Depth is the number of
+// stored key levels from the match, for ancestors and descendants, and 0 for siblings.
+type Neighbor struct {
+	Key   string
+	Value interface{}
+	Depth int
+}
+
+// Neighborhood holds the longest match for a key and the stored keys surrounding it.
+// A stored key is the parent of another when it is its longest stored proper prefix.
+type Neighborhood struct {
+	Match     Neighbor
+	Parent    *Neighbor  // nil if the match has no stored prefix
+	Ancestors []Neighbor // from the parent up to the ancestor depth, nearest first
+	Siblings  []Neighbor // keys branching from the same node as the match in ascending order
+	Children  []Neighbor // descendants up to the descendant depth in ascending order
+}
+
+// neighborDepth holds the number of stored key levels included in a Neighborhood
+type neighborDepth struct {
+	ancestors   int
+	descendants int
+}
+
+// NeighborOpt functional options for Neighbors
+type NeighborOpt func(d *neighborDepth)
+
+// WithAncestorDepth sets the number of ancestor levels included in a Neighborhood, 1 by default
+func WithAncestorDepth(depth int) (opt NeighborOpt) {
+	return func(d *neighborDepth) {
+		d.ancestors = depth
+	}
+}
+
+// WithDescendantDepth sets the number of descendant levels included in a Neighborhood, 1 by default
+func WithDescendantDepth(depth int) (opt NeighborOpt) {
+	return func(d *neighborDepth) {
+		d.descendants = depth
+	}
+}
+
+// Neighbors is like LongestMatch, but returns the longest match with its parent, ancestors,
+// siblings and descendants. Siblings are the first stored keys under the tree node the
+// match branches from, e.g. smart for smaller in a tree with smaller, smallish and smart.
+func (t *Tree) Neighbors(key string, opts ...NeighborOpt) (nb Neighborhood, err error) {
+	d := neighborDepth{ancestors: 1, descendants: 1}
+	for x := 0; x < len(opts); x++ {
+		opts[x](&d)
+	}
+
+	l, n, err := t.longestMatch(t.foldKey(key))
+	if err != nil {
+		return Neighborhood{}, err
+	}
+
+	match := t.originalRange(key, 0, l)
+	nb.Match.Key, nb.Match.Value = n.entry(match)
+
+	// stored ancestors, the first one being the parent of the match
+	for p, pl := n.parent, l-len(n.key); p != nil && len(nb.Ancestors) < d.ancestors; p, pl = p.parent, pl-len(p.key) {
+		if p.value == nil {
+			continue
+		}
+
+		a := Neighbor{Depth: len(nb.Ancestors) + 1}
+		a.Key, a.Value = p.entry(t.originalRange(key, 0, pl))
+		nb.Ancestors = append(nb.Ancestors, a)
+	}
+
+	if len(nb.Ancestors) > 0 {
+		nb.Parent = &nb.Ancestors[0]
+	}
+
+	descendants(n.parent, t.originalRange(key, 0, l-len(n.key)), 1, 1, func(c *node, k string, _ int) {
+		if c != n {
+			s := Neighbor{}
+			s.Key, s.Value = c.entry(k)
+			nb.Siblings = append(nb.Siblings, s)
+		}
+	})
+
+	descendants(n, match, 1, d.descendants, func(c *node, k string, depth int) {
+		s := Neighbor{Depth: depth}
+		s.Key, s.Value = c.entry(k)
+		nb.Children = append(nb.Children, s)
+	})
+
+	return nb, nil
+}
+
+// descendants calls f in ascending order for the stored keys under n, whose key is prefix,
+// up to the given number of stored key levels
+func descendants(n *node, prefix string, depth, max int, f func(c *node, key string, depth int)) {
+	if depth > max {
+		return
+	}
+
+	for x := 0; x < len(n.children); x++ {
+		c := n.children[x]
+		key := prefix + c.key
+
+		if c.value == nil {
+			descendants(c, key, depth, max, f)
+			continue
+		}
+
+		f(c, key, depth)
+		descendants(c, key, depth+1, max, f)
+	}
+}
@@ -0,0 +1,80 @@
+package radixs
+
+import (
+	"testing"
+)
+
+func neighborKeys(ns []Neighbor) (keys []string) {
+	for x := 0; x < len(ns); x++ {
+		keys = append(keys, ns[x].Key)
+	}
+	return keys
+}
+
+func equalKeys(a, b []string) (ok bool) {
+	if len(a) != len(b) {
+		return false
+	}
+	for x := 0; x < len(a); x++ {
+		if a[x] != b[x] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNeighbors(t *testing.T) {
+	assert := newAssert(t)
+	tr, err := FromMap(pairs)
+	assert(err == nil, "error creating tree from map", "err:", err)
+	_ = tr.Set("sma", 677)
+	_ = tr.Set("s", 6)
+
+	nb, err := tr.Neighbors("smallere")
+	assert(err == nil, "error in neighbors, err:", err)
+	assert(nb.Match == Neighbor{Key: "smaller", Value: 81}, "wrong match:", nb.Match)
+	assert(nb.Parent != nil && *nb.Parent == Neighbor{Key: "sma", Value: 677, Depth: 1}, "wrong parent:", nb.Parent)
+	assert(equalKeys(neighborKeys(nb.Ancestors), []string{"sma"}), "wrong ancestors:", nb.Ancestors)
+	assert(equalKeys(neighborKeys(nb.Siblings), []string{"smallish"}), "wrong siblings:", nb.Siblings)
+	assert(equalKeys(neighborKeys(nb.Children), []string{"smallerish"}), "wrong children:", nb.Children)
+
+	nb, err = tr.Neighbors("sma", WithAncestorDepth(3), WithDescendantDepth(2))
+	assert(err == nil, "error in neighbors, err:", err)
+	assert(equalKeys(neighborKeys(nb.Ancestors), []string{"s"}), "wrong ancestors:", nb.Ancestors)
+	expected := []string{"smaller", "smallerish", "smallish", "smart", "smarter", "smarting"}
+	assert(equalKeys(neighborKeys(nb.Children), expected), "expected children:", expected, "got:", nb.Children)
+	for _, c := range nb.Children {
+		depth := 1
+		if c.Key == "smallerish" || c.Key == "smarter" || c.Key == "smarting" {
+			depth = 2
+		}
+		assert(c.Depth == depth, "child:", c.Key, "expected depth:", depth, "got:", c.Depth)
+	}
+
+	nb, _ = tr.Neighbors("smallerish", WithAncestorDepth(2), WithDescendantDepth(0))
+	assert(equalKeys(neighborKeys(nb.Ancestors), []string{"smaller", "sma"}), "wrong ancestors:", nb.Ancestors)
+	assert(nb.Ancestors[1].Depth == 2 && len(nb.Children) == 0, "wrong ancestor depth or children:", nb.Ancestors, nb.Children)
+
+	_, err = tr.Neighbors("x")
+	assert(err == ErrKeyNotFound, "unexpected neighbors for missing key, err:", err)
+}
+
+func TestNeighborsRootParent(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithCaseFolding())
+	_ = tr.Set("Alpha", 1)
+	_ = tr.Set("beta", 2)
+	_ = tr.Set("alphabet", 3)
+
+	nb, err := tr.Neighbors("ALPHAS")
+	assert(err == nil && nb.Match.Key == "Alpha" && nb.Parent == nil, "wrong match or parent:", nb.Match, nb.Parent, "err:", err)
+	assert(equalKeys(neighborKeys(nb.Siblings), []string{"beta"}), "wrong siblings:", nb.Siblings)
+	assert(equalKeys(neighborKeys(nb.Children), []string{"alphabet"}), "wrong children:", nb.Children)
+
+	nb, _ = tr.Neighbors("ALPHABETS")
+	assert(nb.Parent != nil && nb.Parent.Key == "Alpha" && len(nb.Siblings) == 0, "wrong parent or siblings:", nb.Parent, nb.Siblings)
+
+	matches := map[string]interface{}{}
+	err = tr.NeighborMatch("alphas", matches)
+	assert(err == nil && len(matches) == 3, "wrong neighbor matches:", matches, "err:", err)
+}
@@ -8,12 +8,12 @@ type node struct {
 	children []*node     // []*radixs.node: 0-24 (size 24, align 8)
 	key      string      // string: 24-40 (size 16, align 8)
 	value    interface{} // interface{}: 40-56 (size 16, align 8)
-	parent   *node       // *radixs.node: 72-80 (size 8, align 8)
+	parent   *node       // *radixs.node: 56-64 (size 8, align 8)
 }
 
 func (n *node) iter(prefix string, f func(key string, value interface{}) bool) (ok bool) {
 	if n.value != nil {
-		if !f(prefix+n.key, n.value) {
+		if !f(n.entry(prefix + n.key)) {
 			return false
 		}
 	}
@@ -92,6 +92,17 @@ func (n *node) weight() (p uint64) {
 	return p
 }
 
+// fullKey returns the key from the tree root up to and including the current node
+func (n *node) fullKey() (key string) {
+	key = n.key
+	n.reverseWalk(func(n *node) bool {
+		key = n.key + key
+		return true
+	})
+
+	return key
+}
+
 func (n *node) depth() (p uint64) {
 	n.reverseWalk(func(n *node) bool {
 		p++
@@ -111,7 +122,8 @@ func (n *node) string(b *stringBuilder, tab int) {
 	if n.key == "" {
 		b.WriteString("root\n")
 	} else {
-		b.WriteString(fmt.Sprintf("key: %s -> %#v\n", n.key, n.value))
+		_, value := n.entry(n.key)
+		b.WriteString(fmt.Sprintf("key: %s -> %#v\n", n.key, value))
 	}
 
 	tab++
@@ -0,0 +1,178 @@
+// Package openapi exports and imports OpenAPI 3 path skeletons
+// from and into parameterized radixs.Tree keys.
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/brunotm/radixs"
+)
+
+var (
+	ErrInvalidPath = fmt.Errorf("openapi: invalid path")
+)
+
+// Paths is the OpenAPI paths object, holding the path items by path template
+type Paths map[string]*PathItem
+
+// PathItem describes the operations available on a single path
+type PathItem struct {
+	Summary     string      `json:"summary,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Get         *Operation  `json:"get,omitempty"`
+	Put         *Operation  `json:"put,omitempty"`
+	Post        *Operation  `json:"post,omitempty"`
+	Delete      *Operation  `json:"delete,omitempty"`
+	Options     *Operation  `json:"options,omitempty"`
+	Head        *Operation  `json:"head,omitempty"`
+	Patch       *Operation  `json:"patch,omitempty"`
+	Trace       *Operation  `json:"trace,omitempty"`
+	Parameters  []Parameter `json:"parameters,omitempty"`
+}
+
+// Operation describes a single API operation on a path
+type Operation struct {
+	OperationID string              `json:"operationId,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	Responses   map[string]Response `json:"responses,omitempty"`
+}
+
+// Parameter describes a single operation parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// Schema describes a parameter data type
+type Schema struct {
+	Type   string `json:"type,omitempty"`
+	Format string `json:"format,omitempty"`
+}
+
+// Response describes a single response from an operation
+type Response struct {
+	Description string `json:"description"`
+}
+
+// Export walks the tree keys and builds the OpenAPI paths, converting parameter
+// and wildcard segments into {name} templates declared as required path parameters,
+// typed after their constraints or as strings if they have none.
+// If not nil, f is called for each route allowing the caller to attach operations
+// and metadata to its path item based on the route value.
+func Export(t *radixs.Tree, f func(route radixs.Route, item *PathItem)) (paths Paths) {
+	paths = Paths{}
+
+	routes := t.Routes()
+	for x := 0; x < len(routes); x++ {
+		b := strings.Builder{}
+		item := &PathItem{}
+
+		for _, s := range routes[x].Segments {
+			if s.Kind == radixs.SegmentStatic {
+				b.WriteString(s.Value)
+				continue
+			}
+
+			b.WriteString("{" + s.Value + "}")
+			item.Parameters = append(item.Parameters, Parameter{
+				Name:     s.Value,
+				In:       "path",
+				Required: true,
+				Schema:   schema(s.Constraint),
+			})
+		}
+
+		if f != nil {
+			f(routes[x], item)
+		}
+
+		paths[b.String()] = item
+	}
+
+	return paths
+}
+
+// schema returns the schema for a parameter with the given type constraint
+func schema(constraint string) (s *Schema) {
+	switch constraint {
+	case "int", "uint":
+		return &Schema{Type: "integer"}
+	case "int64":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "uuid":
+		return &Schema{Type: "string", Format: "uuid"}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// Import sets the OpenAPI paths as parameterized keys in the tree, using the
+// tree configured delimiter and parameter placeholder. The value for each key
+// is obtained by calling f with the path template and item. Paths are set in
+// ascending lexicographic order and Import stops at the first error.
+func Import(t *radixs.Tree, paths Paths, f func(path string, item *PathItem) interface{}) (err error) {
+	templates := make([]string, 0, len(paths))
+	for path := range paths {
+		templates = append(templates, path)
+	}
+	sort.Strings(templates)
+
+	for _, path := range templates {
+		segments, err := parsePath(path)
+		if err != nil {
+			return err
+		}
+
+		key, err := t.Pattern(segments)
+		if err != nil {
+			return fmt.Errorf("openapi: path %q: %w", path, err)
+		}
+
+		if err = t.SetWithParams(key, f(path, paths[path])); err != nil {
+			return fmt.Errorf("openapi: path %q: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// parsePath parses an OpenAPI path template into static and parameter segments
+func parsePath(path string) (segments []radixs.Segment, err error) {
+	static := 0
+	for x := 0; x < len(path); x++ {
+		switch path[x] {
+		case '}':
+			return nil, fmt.Errorf("%w: unexpected '}' at offset %d in %q", ErrInvalidPath, x, path)
+
+		case '{':
+			end := strings.IndexByte(path[x:], '}')
+			if end < 2 {
+				return nil, fmt.Errorf("%w: unterminated or empty parameter at offset %d in %q", ErrInvalidPath, x, path)
+			}
+
+			if static < x {
+				segments = append(segments, radixs.Segment{Kind: radixs.SegmentStatic, Value: path[static:x], Offset: static})
+			}
+
+			segments = append(segments, radixs.Segment{Kind: radixs.SegmentParam, Value: path[x+1 : x+end], Offset: x})
+			x += end
+			static = x + 1
+		}
+	}
+
+	if static < len(path) {
+		segments = append(segments, radixs.Segment{Kind: radixs.SegmentStatic, Value: path[static:], Offset: static})
+	}
+
+	return segments, nil
+}
@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/brunotm/radixs"
+)
+
+func newAssert(t testing.TB) func(cond bool, kvs ...interface{}) {
+	return func(cond bool, args ...interface{}) {
+		if !cond {
+			t.Helper()
+			t.Error(args...)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	assert := newAssert(t)
+	tr := radixs.New(radixs.WithParams('/', ':'), radixs.WithWildcard('*'))
+
+	_ = tr.SetWithParams("/api/v1/projects", "ListProjects")
+	_ = tr.SetWithParams("/api/v1/projects/:project/instances/:instance", "GetInstance")
+	_ = tr.SetWithParams("/files/*path", "GetFile")
+
+	paths := Export(tr, func(route radixs.Route, item *PathItem) {
+		item.Get = &Operation{
+			OperationID: route.Value.(string),
+			Responses:   map[string]Response{"200": {Description: "OK"}},
+		}
+	})
+
+	assert(len(paths) == 3, "expected 3 paths, got:", len(paths))
+
+	item := paths["/api/v1/projects/{project}/instances/{instance}"]
+	assert(item != nil, "missing parameterized path:", paths)
+	if item != nil {
+		assert(item.Get != nil && item.Get.OperationID == "GetInstance", "wrong operation:", item.Get)
+		assert(len(item.Parameters) == 2 &&
+			item.Parameters[0].Name == "project" && item.Parameters[1].Name == "instance" &&
+			item.Parameters[0].In == "path" && item.Parameters[0].Required,
+			"wrong path parameters:", item.Parameters)
+	}
+
+	item = paths["/files/{path}"]
+	assert(item != nil && len(item.Parameters) == 1 && item.Parameters[0].Name == "path", "wrong wildcard path:", item)
+
+	item = paths["/api/v1/projects"]
+	assert(item != nil && len(item.Parameters) == 0, "wrong static path:", item)
+
+	b, err := json.Marshal(paths["/api/v1/projects"])
+	expected := `{"get":{"operationId":"ListProjects","responses":{"200":{"description":"OK"}}}}`
+	assert(err == nil && string(b) == expected, "expected json:", expected, "got:", string(b), "err:", err)
+}
+
+func TestImport(t *testing.T) {
+	assert := newAssert(t)
+	tr := radixs.New(radixs.WithParams(':', '@'))
+
+	paths := Paths{
+		"urn:accounts:{account}":                      {Get: &Operation{OperationID: "GetAccount"}},
+		"urn:accounts:{account}:resources:{resource}": {Get: &Operation{OperationID: "GetResource"}},
+	}
+
+	err := Import(tr, paths, func(path string, item *PathItem) interface{} {
+		return item.Get.OperationID
+	})
+	assert(err == nil, "error importing paths:", err)
+
+	params := map[string]string{}
+	pattern, value, err := tr.Match("urn:accounts:E7B4320A06A1:resources:46D05077510E", params)
+	assert(err == nil, "error matching imported path:", err)
+	assert(pattern == "urn:accounts:@account:resources:@resource", "wrong pattern:", pattern)
+	assert(value == "GetResource", "wrong value:", value)
+	assert(params["account"] == "E7B4320A06A1" && params["resource"] == "46D05077510E", "wrong params:", params)
+
+	err = Import(tr, Paths{"urn:accounts:{id}": {}}, func(string, *PathItem) interface{} { return "conflict" })
+	assert(errors.Is(err, radixs.ErrConflictKey), "expected conflict importing path, got:", err)
+
+	for _, path := range []string{"/users/{id", "/users/{}", "/users/id}"} {
+		err = Import(radixs.New(radixs.WithParams('/', ':')), Paths{path: {}},
+			func(string, *PathItem) interface{} { return path })
+		assert(errors.Is(err, ErrInvalidPath), "path:", path, "expected invalid path, got:", err)
+	}
+
+	// parameter names must be valid in the tree syntax
+	tr = radixs.New(radixs.WithParams('/', ':'))
+	err = Import(tr, Paths{"/users/{user-id}": {}}, func(string, *PathItem) interface{} { return "user" })
+	assert(errors.Is(err, radixs.ErrInvalidKey), "expected invalid parameter name, got:", err)
+	assert(tr.Size() == 0, "path with invalid parameter name imported, size:", tr.Size())
+}
+
+func TestRoundTrip(t *testing.T) {
+	assert := newAssert(t)
+	src := radixs.New(radixs.WithParams('/', ':'))
+	_ = src.SetWithParams("/users/:user", "user")
+	_ = src.SetWithParams("/users/:user/repos/:repo", "repo")
+
+	dst := radixs.New(radixs.WithParams('/', '$'))
+	err := Import(dst, Export(src, nil), func(path string, _ *PathItem) interface{} { return path })
+	assert(err == nil, "error importing exported paths:", err)
+
+	routes := dst.Routes()
+	assert(len(routes) == 2 &&
+		routes[0].Pattern == "/users/$user" && routes[0].Value == "/users/{user}" &&
+		routes[1].Pattern == "/users/$user/repos/$repo" && routes[1].Value == "/users/{user}/repos/{repo}",
+		"wrong imported routes:", routes)
+}
+
+func TestExportConstraints(t *testing.T) {
+	assert := newAssert(t)
+	tr := radixs.New(radixs.WithParams('/', ':'), radixs.WithParamConstraints('|'))
+
+	_ = tr.SetWithParams("/users/:id|int64/keys/:key|uuid", "GetKey")
+
+	item := Export(tr, nil)["/users/{id}/keys/{key}"]
+	assert(item != nil && len(item.Parameters) == 2, "wrong constrained path:", item)
+	if item != nil && len(item.Parameters) == 2 {
+		assert(*item.Parameters[0].Schema == Schema{Type: "integer", Format: "int64"}, "wrong schema:", item.Parameters[0].Schema)
+		assert(*item.Parameters[1].Schema == Schema{Type: "string", Format: "uuid"}, "wrong schema:", item.Parameters[1].Schema)
+	}
+}
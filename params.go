@@ -0,0 +1,149 @@
+package radixs
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Params holds the parameters extracted by GetWithParams and Match
+// and provides typed accessors for their values
+type Params map[string]string
+
+// ParamError describes a parameter whose value cannot be converted to the requested type.
+// It wraps ErrInvalidParam so it can be checked with errors.Is.
+type ParamError struct {
+	Name  string // parameter name
+	Value string // raw parameter value, empty if the parameter is missing
+	Type  string // requested type
+}
+
+// Error implements the error interface
+func (e *ParamError) Error() (s string) {
+	return fmt.Sprintf("%s: %q for parameter %q is not a valid %s", ErrInvalidParam, e.Value, e.Name, e.Type)
+}
+
+// Unwrap returns ErrInvalidParam
+func (e *ParamError) Unwrap() (err error) {
+	return ErrInvalidParam
+}
+
+// Int returns the named parameter as an int
+func (p Params) Int(name string) (v int, err error) {
+	value := p[name]
+	if v, err = strconv.Atoi(value); err != nil {
+		return 0, &ParamError{Name: name, Value: value, Type: "int"}
+	}
+
+	return v, nil
+}
+
+// Int64 returns the named parameter as an int64
+func (p Params) Int64(name string) (v int64, err error) {
+	value := p[name]
+	if v, err = strconv.ParseInt(value, 10, 64); err != nil {
+		return 0, &ParamError{Name: name, Value: value, Type: "int64"}
+	}
+
+	return v, nil
+}
+
+// Uint returns the named parameter as an uint
+func (p Params) Uint(name string) (v uint, err error) {
+	value := p[name]
+	u, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		return 0, &ParamError{Name: name, Value: value, Type: "uint"}
+	}
+
+	return uint(u), nil
+}
+
+// Bool returns the named parameter as a bool, accepting the values
+// accepted by strconv.ParseBool
+func (p Params) Bool(name string) (v bool, err error) {
+	value := p[name]
+	if v, err = strconv.ParseBool(value); err != nil {
+		return false, &ParamError{Name: name, Value: value, Type: "bool"}
+	}
+
+	return v, nil
+}
+
+// UUID returns the named parameter if it has the textual UUID
+// form xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx with hexadecimal digits
+func (p Params) UUID(name string) (v string, err error) {
+	value := p[name]
+	if !isUUID(value) {
+		return "", &ParamError{Name: name, Value: value, Type: "uuid"}
+	}
+
+	return value, nil
+}
+
+// Unescape returns the named parameter with percent-encoded bytes decoded
+func (p Params) Unescape(name string) (v string, err error) {
+	value, ok := p[name]
+	if !ok {
+		return "", &ParamError{Name: name, Value: value, Type: "escaped string"}
+	}
+
+	if v, err = url.PathUnescape(value); err != nil {
+		return "", &ParamError{Name: name, Value: value, Type: "escaped string"}
+	}
+
+	return v, nil
+}
+
+// isParamType checks if kind is a supported parameter type constraint
+func isParamType(kind string) (ok bool) {
+	switch kind {
+	case "int", "int64", "uint", "bool", "uuid":
+		return true
+	}
+
+	return false
+}
+
+// checkParam checks if value satisfies the parameter type constraint
+func checkParam(kind, value string) (ok bool) {
+	var err error
+
+	switch kind {
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "int64":
+		_, err = strconv.ParseInt(value, 10, 64)
+	case "uint":
+		_, err = strconv.ParseUint(value, 10, 0)
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "uuid":
+		return isUUID(value)
+	}
+
+	return err == nil
+}
+
+// isUUID checks if s has the textual UUID form
+func isUUID(s string) (ok bool) {
+	if len(s) != 36 {
+		return false
+	}
+
+	for x := 0; x < len(s); x++ {
+		switch x {
+		case 8, 13, 18, 23:
+			if s[x] != '-' {
+				return false
+			}
+		default:
+			c := s[x]
+			if !('0' <= c && c <= '9') && !('a' <= c && c <= 'f') && !('A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,108 @@
+package radixs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParams(t *testing.T) {
+	assert := newAssert(t)
+	p := Params{
+		"id":    "42",
+		"big":   "9223372036854775807",
+		"neg":   "-1",
+		"flag":  "true",
+		"uuid":  "123e4567-E89B-12d3-a456-426614174000",
+		"name":  "hello%20world",
+		"bad":   "4x2",
+		"badid": "123e4567-e89b-12d3-a456-42661417400g",
+		"esc":   "%zz",
+	}
+
+	i, err := p.Int("id")
+	assert(err == nil && i == 42, "int:", i, "err:", err)
+
+	i64, err := p.Int64("big")
+	assert(err == nil && i64 == 9223372036854775807, "int64:", i64, "err:", err)
+
+	u, err := p.Uint("id")
+	assert(err == nil && u == 42, "uint:", u, "err:", err)
+
+	b, err := p.Bool("flag")
+	assert(err == nil && b, "bool:", b, "err:", err)
+
+	id, err := p.UUID("uuid")
+	assert(err == nil && id == p["uuid"], "uuid:", id, "err:", err)
+
+	s, err := p.Unescape("name")
+	assert(err == nil && s == "hello world", "unescape:", s, "err:", err)
+
+	tests := []struct {
+		name string
+		typ  string
+		f    func(name string) error
+	}{
+		{"bad", "int", func(name string) (err error) { _, err = p.Int(name); return err }},
+		{"missing", "int", func(name string) (err error) { _, err = p.Int(name); return err }},
+		{"bad", "int64", func(name string) (err error) { _, err = p.Int64(name); return err }},
+		{"neg", "uint", func(name string) (err error) { _, err = p.Uint(name); return err }},
+		{"id", "bool", func(name string) (err error) { _, err = p.Bool(name); return err }},
+		{"badid", "uuid", func(name string) (err error) { _, err = p.UUID(name); return err }},
+		{"esc", "escaped string", func(name string) (err error) { _, err = p.Unescape(name); return err }},
+		{"missing", "escaped string", func(name string) (err error) { _, err = p.Unescape(name); return err }},
+	}
+
+	for _, tt := range tests {
+		err := tt.f(tt.name)
+		assert(errors.Is(err, ErrInvalidParam), "param:", tt.name, "type:", tt.typ, "expected ErrInvalidParam, got:", err)
+
+		var perr *ParamError
+		assert(errors.As(err, &perr) && perr.Name == tt.name && perr.Value == p[tt.name] && perr.Type == tt.typ,
+			"param:", tt.name, "type:", tt.typ, "wrong error:", err)
+	}
+}
+
+func TestParamConstraints(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithParamConstraints('|'), WithWildcard('*'))
+
+	keys := []string{"/users/:id|int", "/users/:id|int/active/:active|bool", "/keys/:key|uuid.json"}
+	for _, key := range keys {
+		err := tr.SetWithParams(key, key)
+		assert(err == nil, "error setting key:", key, "error:", err)
+	}
+
+	params := map[string]string{}
+	value, err := tr.GetWithParams("/users/42/active/true", params)
+	assert(err == nil && value == keys[1], "expected:", keys[1], "got:", value, "err:", err)
+	assert(params["id"] == "42" && params["active"] == "true", "wrong params:", params)
+
+	pattern, _, err := tr.Match("/keys/123e4567-e89b-12d3-a456-426614174000.json", map[string]string{})
+	assert(err == nil && pattern == keys[2], "expected:", keys[2], "got:", pattern, "err:", err)
+
+	_, err = tr.GetWithParams("/users/bob", map[string]string{})
+	var perr *ParamError
+	assert(errors.As(err, &perr) && perr.Name == "id" && perr.Value == "bob" && perr.Type == "int", "wrong error:", err)
+
+	tests := []struct {
+		key string
+		err error
+	}{
+		{"/users/:id|str", ErrInvalidKey},
+		{"/users/:id|", ErrInvalidKey},
+		{"/users/:id|uuid", ErrConflictKey},
+		{"/users/:id", ErrConflictKey},
+		{"/files/*path|int", nil},
+	}
+
+	for _, tt := range tests {
+		err := tr.SetWithParams(tt.key, tt.key)
+		assert(errors.Is(err, tt.err), "key:", tt.key, "expected:", tt.err, "got:", err)
+	}
+
+	routes := tr.Routes()
+	for _, r := range routes {
+		pattern, err := tr.Pattern(r.Segments)
+		assert(err == nil && pattern == r.Pattern, "pattern:", r.Pattern, "rebuilt as:", pattern, "err:", err)
+	}
+}
@@ -0,0 +1,226 @@
+package radixs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PatternError describes a malformed key set with SetWithParams.
+// It wraps ErrInvalidKey so it can be checked with errors.Is.
+type PatternError struct {
+	Key    string // malformed key
+	Offset int    // byte offset in Key of the malformed construct
+	Reason string // description of the malformed construct
+}
+
+// Error implements the error interface
+func (e *PatternError) Error() (s string) {
+	return fmt.Sprintf("%s: %s at offset %d in %q", ErrInvalidKey, e.Reason, e.Offset, e.Key)
+}
+
+// Unwrap returns ErrInvalidKey
+func (e *PatternError) Unwrap() (err error) {
+	return ErrInvalidKey
+}
+
+// SegmentKind is the kind of a key pattern segment
+type SegmentKind uint8
+
+const (
+	SegmentStatic   SegmentKind = iota // literal text, including delimiters
+	SegmentParam                       // parameter, matching up to the next delimiter or literal
+	SegmentWildcard                    // wildcard, matching the remaining of the key
+)
+
+// String returns the segment kind name
+func (k SegmentKind) String() (s string) {
+	switch k {
+	case SegmentStatic:
+		return "static"
+	case SegmentParam:
+		return "param"
+	case SegmentWildcard:
+		return "wildcard"
+	default:
+		return fmt.Sprintf("SegmentKind(%d)", uint8(k))
+	}
+}
+
+// Segment is a part of a key pattern. Static segments hold literal text
+// while parameter and wildcard segments hold the parameter name.
+type Segment struct {
+	Kind       SegmentKind
+	Value      string
+	Constraint string // parameter type constraint, if any
+	Offset     int    // byte offset of the segment in the key pattern
+}
+
+// Route describes a key pattern stored in the tree
+type Route struct {
+	Pattern  string
+	Segments []Segment
+	Params   []string // parameter and wildcard names in the order they appear in Pattern
+	Value    interface{}
+}
+
+// Pattern builds a key pattern from the given segments using the tree delimiter,
+// parameter and wildcard placeholders. It is the inverse of the segments in Routes.
+// Parameter and wildcard names must be valid parameter names, otherwise ErrInvalidKey is returned.
+func (t *Tree) Pattern(segments []Segment) (key string, err error) {
+	b := &stringBuilder{}
+	for x := 0; x < len(segments); x++ {
+		if segments[x].Kind != SegmentStatic {
+			if name := segments[x].Value; name == "" || t.paramName(name) != len(name) {
+				return "", ErrInvalidKey
+			}
+		}
+
+		switch segments[x].Kind {
+		case SegmentStatic:
+			b.WriteString(segments[x].Value)
+		case SegmentParam:
+			b.WriteString(t.open)
+			b.WriteString(segments[x].Value)
+			if err = t.writeConstraint(b, segments[x].Constraint); err != nil {
+				return "", err
+			}
+			b.WriteString(t.close)
+		case SegmentWildcard:
+			if t.wildcard == 0 {
+				return "", ErrInvalidKey
+			}
+			b.buf = append(b.buf, t.wildcard)
+			b.WriteString(segments[x].Value)
+			if err = t.writeConstraint(b, segments[x].Constraint); err != nil {
+				return "", err
+			}
+		default:
+			return "", ErrInvalidKey
+		}
+	}
+
+	return b.String(), nil
+}
+
+// writeConstraint writes the parameter type constraint, if any, to b
+func (t *Tree) writeConstraint(b *stringBuilder, constraint string) (err error) {
+	if constraint == "" {
+		return nil
+	}
+
+	if t.constraint == 0 {
+		return ErrInvalidKey
+	}
+
+	b.buf = append(b.buf, t.constraint)
+	b.WriteString(constraint)
+	return nil
+}
+
+// validateKey checks the key for malformed constructs with delimiters and parameters
+func (t *Tree) validateKey(key string) (err error) {
+	return t.parsePattern(key, nil)
+}
+
+// parsePattern scans the key pattern calling f, if not nil, for each of its segments.
+// It returns a *PatternError for the first malformed construct found:
+// a delimiter followed by a delimiter, incomplete or unterminated parameter tokens,
+// a parameter without a name, unknown type constraints, adjacent parameters,
+// a wildcard not at the end of the key and parameter names used more than once.
+func (t *Tree) parsePattern(key string, f func(s Segment)) (err error) {
+	// avoid allocations for patterns with a reasonable number of parameters
+	var buf [8]string
+	names := buf[:0]
+
+	static := 0
+	for x := 0; x < len(key); x++ {
+		// skip literal bytes
+		if t.class[key[x]]&(classDelimiter|classParam|classClose) == 0 {
+			continue
+		}
+
+		switch {
+		case t.isDelimiter(key[x]):
+			if x+1 < len(key) && t.isDelimiter(key[x+1]) {
+				return &PatternError{Key: key, Offset: x + 1, Reason: "empty segment"}
+			}
+
+		case t.isParamByte(key[x]):
+			name, kind, rest, wildcard, reason := t.paramToken(key[x:])
+			if reason != "" {
+				return &PatternError{Key: key, Offset: len(key) - len(rest), Reason: reason}
+			}
+
+			if kind != "" && !isParamType(kind) {
+				offset := len(key) - len(rest) - len(kind)
+				if !wildcard {
+					offset -= len(t.close)
+				}
+				return &PatternError{Key: key, Offset: offset, Reason: fmt.Sprintf("unknown parameter constraint %q", kind)}
+			}
+
+			end := len(key) - len(rest)
+			if end < len(key) {
+				switch {
+				case wildcard:
+					return &PatternError{Key: key, Offset: end, Reason: "wildcard must be at the end of the key"}
+				case t.isParamByte(key[end]) && t.close == "":
+					return &PatternError{Key: key, Offset: end, Reason: "parameter placeholder inside parameter name"}
+				case t.isParamByte(key[end]):
+					return &PatternError{Key: key, Offset: end, Reason: "adjacent parameters"}
+				}
+			}
+
+			for y := 0; y < len(names); y++ {
+				if names[y] == name {
+					return &PatternError{Key: key, Offset: x, Reason: fmt.Sprintf("duplicate parameter name %q", name)}
+				}
+			}
+			names = append(names, name)
+
+			if f != nil {
+				segment := SegmentParam
+				if wildcard {
+					segment = SegmentWildcard
+				}
+
+				if static < x {
+					f(Segment{Kind: SegmentStatic, Value: key[static:x], Offset: static})
+				}
+				f(Segment{Kind: segment, Value: name, Constraint: kind, Offset: x})
+			}
+
+			x = end - 1
+			static = end
+
+		case t.class[key[x]]&classClose != 0 && strings.HasPrefix(key[x:], t.close):
+			return &PatternError{Key: key, Offset: x, Reason: "unexpected parameter closing token"}
+		}
+	}
+
+	if f != nil && static < len(key) {
+		f(Segment{Kind: SegmentStatic, Value: key[static:], Offset: static})
+	}
+
+	return nil
+}
+
+// route parses the given key into a Route. Keys which are not valid
+// patterns, as the ones set with Set, have a single static segment.
+func (t *Tree) route(key string, value interface{}) (r Route) {
+	r = Route{Pattern: key, Value: value}
+
+	err := t.parsePattern(key, func(s Segment) {
+		r.Segments = append(r.Segments, s)
+		if s.Kind != SegmentStatic {
+			r.Params = append(r.Params, s.Value)
+		}
+	})
+
+	if err != nil {
+		r.Segments = []Segment{{Kind: SegmentStatic, Value: key}}
+		r.Params = nil
+	}
+
+	return r
+}
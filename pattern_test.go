@@ -0,0 +1,188 @@
+package radixs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPatternErrors(t *testing.T) {
+	assert := newAssert(t)
+
+	tests := []struct {
+		key    string
+		offset int
+		reason string
+	}{
+		{"/api/v1/projects//:project", 17, "empty segment"},
+		{"/api/v1:/projects", 7, "empty parameter name"},
+		{"/api/v1/projects/::project", 17, "empty parameter name"},
+		{"/api/v1/projects/:", 17, "empty parameter name"},
+		{":", 0, "empty parameter name"},
+		{"/img/:name:ext", 10, "parameter placeholder inside parameter name"},
+		{"/users/:id/repos/:id", 17, `duplicate parameter name "id"`},
+		{"//", 1, "empty segment"},
+	}
+
+	for _, tt := range tests {
+		tr := New(WithParams('/', ':'))
+		err := tr.SetWithParams(tt.key, tt.key)
+		assert(errors.Is(err, ErrInvalidKey), "key:", tt.key, "expected invalid key, got:", err)
+
+		var pe *PatternError
+		if !errors.As(err, &pe) {
+			t.Error("key:", tt.key, "expected pattern error, got:", err)
+			continue
+		}
+
+		assert(pe.Key == tt.key, "key:", tt.key, "wrong error key:", pe.Key)
+		assert(pe.Offset == tt.offset, "key:", tt.key, "expected offset:", tt.offset, "got:", pe.Offset)
+		assert(pe.Reason == tt.reason, "key:", tt.key, "expected reason:", tt.reason, "got:", pe.Reason)
+	}
+
+	for _, key := range []string{"/", "/users/", "/users/:id/", "/img/:name.:ext"} {
+		tr := New(WithParams('/', ':'))
+		err := tr.SetWithParams(key, key)
+		assert(err == nil, "key:", key, "should be valid, err:", err)
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'))
+
+	var parts []string
+	err := tr.parsePattern("/v:major.:minor/docs/:page", func(s Segment) {
+		if s.Kind == SegmentParam {
+			parts = append(parts, ":"+s.Value)
+			return
+		}
+		parts = append(parts, s.Value)
+	})
+
+	expected := "/v|:major|.|:minor|/docs/|:page"
+	assert(err == nil, "error parsing pattern:", err)
+	assert(strings.Join(parts, "|") == expected, "expected segments:", expected, "got:", strings.Join(parts, "|"))
+}
+
+var paramKeys = []string{
+	"/api/v1/projects/:project",
+	"/api/v1/projects/:project/instances/:instance",
+	"/api/v1/projects/:project/instances/:instance/databases/:database",
+	"/api/v1/projects//:project/instances/:instance/databases/:database",
+	"/api/v1:/projects/:project/instances/:instance/databases/:database",
+	"/api/v1/projects/::project/instances/:instance/databases/:database",
+	"/api/v1/projects/project/instances/:instance/databases/:database",
+	"/img/:name.:ext",
+	"/img/:a:b",
+	"/users/",
+	"/users/:",
+	"/",
+}
+
+func FuzzSetWithParams(f *testing.F) {
+	for _, key := range paramKeys {
+		f.Add(key)
+	}
+
+	f.Fuzz(func(t *testing.T, key string) {
+		tr := New(WithParams('/', ':'))
+		for _, k := range paramKeys[:3] {
+			_ = tr.SetWithParams(k, k)
+		}
+
+		checkErr := tr.CheckPattern(key)
+		err := tr.SetWithParams(key, key)
+
+		if (checkErr == nil) != (err == nil) {
+			t.Fatalf("check pattern and set results differ for key %q, check: %v, set: %v", key, checkErr, err)
+		}
+
+		if err != nil {
+			if !errors.Is(err, ErrInvalidKey) && !errors.Is(err, ErrConflictKey) && err != ErrEmptyKey {
+				t.Fatalf("unexpected error for key %q: %v", key, err)
+			}
+			return
+		}
+
+		if value, err := tr.Get(key); err != nil || value != key {
+			t.Fatalf("key %q not found after set, value: %v, err: %v", key, value, err)
+		}
+	})
+}
+
+func FuzzGetWithParams(f *testing.F) {
+	f.Add("/api/v1/projects/01FW1D5RWNR6MEZDJZZYJX8G2W/instances/31459")
+	f.Add("/api/v1/projects/01FW1D5RWNR6MEZDJZZYJX8G2W/instances/31459/databases/ordersdb")
+	f.Add("/img/logo.png")
+	f.Add("/api/v1/projects/")
+	f.Add("/")
+
+	tr := New(WithParams('/', ':'))
+	for _, k := range paramKeys {
+		_ = tr.SetWithParams(k, k)
+	}
+
+	f.Fuzz(func(t *testing.T, key string) {
+		_, _ = tr.GetWithParams(key, map[string]string{})
+	})
+}
+
+func TestRoutes(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithWildcard('*'))
+
+	_ = tr.SetWithParams("/users/:user/repos/:repo", "repo")
+	_ = tr.SetWithParams("/files/*path", "files")
+	_ = tr.SetWithParams("/health", "health")
+
+	routes := tr.Routes()
+	assert(len(routes) == 3, "expected 3 routes, got:", len(routes))
+	if len(routes) != 3 {
+		return
+	}
+
+	expected := []struct {
+		pattern  string
+		segments string
+		params   string
+		value    interface{}
+	}{
+		{"/files/*path", "static:/files/|wildcard:path", "path", "files"},
+		{"/health", "static:/health", "", "health"},
+		{"/users/:user/repos/:repo", "static:/users/|param:user|static:/repos/|param:repo", "user,repo", "repo"},
+	}
+
+	for x, e := range expected {
+		r := routes[x]
+		var segments []string
+		for _, s := range r.Segments {
+			segments = append(segments, s.Kind.String()+":"+s.Value)
+		}
+
+		assert(r.Pattern == e.pattern, "expected pattern:", e.pattern, "got:", r.Pattern)
+		assert(strings.Join(segments, "|") == e.segments, "pattern:", r.Pattern, "expected segments:", e.segments, "got:", segments)
+		assert(strings.Join(r.Params, ",") == e.params, "pattern:", r.Pattern, "expected params:", e.params, "got:", r.Params)
+		assert(r.Value == e.value, "pattern:", r.Pattern, "expected value:", e.value, "got:", r.Value)
+	}
+}
+
+func TestWildcardPatternErrors(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithParams('/', ':'), WithWildcard('*'))
+
+	err := tr.SetWithParams("/files/*path/raw", "raw")
+	assert(errors.Is(err, ErrInvalidKey), "wildcard not at the end, err:", err)
+
+	err = tr.SetWithParams("/files/*", "files")
+	assert(errors.Is(err, ErrInvalidKey), "wildcard without name, err:", err)
+
+	err = tr.SetWithParams("/files/*path", "files")
+	assert(err == nil, "error setting wildcard key, err:", err)
+
+	err = tr.SetWithParams("/files/:name", "name")
+	assert(errors.Is(err, ErrConflictKey), "param conflicting with wildcard, err:", err)
+
+	err = tr.SetWithParams("/files/readme", "readme")
+	assert(errors.Is(err, ErrConflictKey), "literal conflicting with wildcard, err:", err)
+}
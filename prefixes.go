@@ -0,0 +1,70 @@
+package radixs
+
+import (
+	"sort"
+	"strings"
+)
+
+// Prefixes calls f for each stored key that is a prefix of the given key, including
+// the key itself, from the shortest to the longest, as in layered configuration lookups.
+// In trees with case folding the prefixes are the keys as they were registered.
+// It does a single downward pass and does not allocate for keys already folded.
+// If f returns false, Prefixes stops the iteration.
+func (t *Tree) Prefixes(key string, f func(prefix string, value interface{}) bool) {
+	original := key
+	t.prefixes(t.foldKey(key), func(n *node, depth int) bool {
+		return f(n.entry(t.originalRange(original, 0, depth)))
+	})
+}
+
+// ShortestMatch is like LongestMatch, but returns the shortest stored prefix of the key
+func (t *Tree) ShortestMatch(key string) (match string, value interface{}, err error) {
+	if key == "" {
+		return "", nil, ErrEmptyKey
+	}
+
+	original := key
+	var found *node
+	var depth int
+	t.prefixes(t.foldKey(key), func(n *node, d int) bool {
+		found, depth = n, d
+		return false
+	})
+
+	if found == nil {
+		return "", nil, ErrKeyNotFound
+	}
+
+	match, value = found.entry(t.originalRange(original, 0, depth))
+	return match, value, nil
+}
+
+// prefixes calls f for each node with a value along the key path
+// with the length of the key prefix it holds
+func (t *Tree) prefixes(key string, f func(n *node, depth int) bool) {
+	n := t.root
+	depth := 0
+	for {
+		if !strings.HasPrefix(key[depth:], n.key) {
+			return
+		}
+		depth += len(n.key)
+
+		if n.value != nil && !f(n, depth) {
+			return
+		}
+
+		if depth == len(key) {
+			return
+		}
+
+		i := sort.Search(len(n.children), func(x int) bool {
+			return n.children[x].key[0] >= key[depth]
+		})
+
+		if i >= len(n.children) {
+			return
+		}
+		n = n.children[i]
+	}
+}
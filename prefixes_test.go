@@ -0,0 +1,72 @@
+package radixs
+
+import (
+	"testing"
+)
+
+func TestPrefixes(t *testing.T) {
+	assert := newAssert(t)
+	tr := New()
+
+	layers := []string{"/", "/org", "/org/team", "/org/team/svc"}
+	for _, k := range layers {
+		_ = tr.Set(k, k)
+	}
+	_ = tr.Set("/org/teams", "/org/teams")
+	_ = tr.Set("/other", "/other")
+
+	var found []string
+	tr.Prefixes("/org/team/svc/config", func(prefix string, value interface{}) bool {
+		assert(value == prefix, "prefix:", prefix, "wrong value:", value)
+		found = append(found, prefix)
+		return true
+	})
+
+	assert(len(found) == len(layers), "expected:", layers, "got:", found)
+	for x := 0; x < len(found) && x < len(layers); x++ {
+		assert(found[x] == layers[x], "expected:", layers, "got:", found)
+	}
+
+	found = nil
+	tr.Prefixes("/org/team", func(prefix string, value interface{}) bool {
+		found = append(found, prefix)
+		return len(found) < 2
+	})
+	assert(len(found) == 2 && found[1] == "/org", "iteration not stopped:", found)
+
+	match, value, err := tr.ShortestMatch("/org/team/svc")
+	assert(err == nil && match == "/" && value == "/", "wrong shortest match:", match, value, "err:", err)
+
+	_, _, err = tr.ShortestMatch("org")
+	assert(err == ErrKeyNotFound, "unexpected shortest match, err:", err)
+
+	_, _, err = tr.ShortestMatch("")
+	assert(err == ErrEmptyKey, "unexpected shortest match for empty key, err:", err)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		tr.Prefixes("/org/team/svc/config", func(prefix string, value interface{}) bool { return true })
+		_, _, _ = tr.ShortestMatch("/org/team/svc")
+	})
+	assert(allocs == 0, "expected no allocations, got:", allocs)
+}
+
+func TestPrefixesCaseFolding(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithUnicodeCaseFolding())
+
+	_ = tr.Set("/Straße", 1)
+	_ = tr.Set("/straße/Ünit", 2)
+
+	var found []string
+	tr.Prefixes("/STRASSE/ÜNIT/x", func(prefix string, value interface{}) bool {
+		found = append(found, prefix)
+		return true
+	})
+	assert(len(found) == 0, "unexpected prefixes:", found)
+
+	tr.Prefixes("/STRAßE/ÜNIT/x", func(prefix string, value interface{}) bool {
+		found = append(found, prefix)
+		return true
+	})
+	assert(len(found) == 2 && found[0] == "/Straße" && found[1] == "/straße/Ünit", "wrong prefixes:", found)
+}
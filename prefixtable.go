@@ -0,0 +1,359 @@
+package radixs
+
+import (
+	"net/netip"
+	"sort"
+)
+
+// PrefixTable is a compact binary radix tree of IP prefixes supporting longest
+// prefix matches at bit granularity, with separate roots for IPv4 and IPv6.
+// Node edges are compressed as in Tree, with their lengths in bits.
+type PrefixTable[V any] struct {
+	size uint64
+	v4   *prefixNode[V]
+	v6   *prefixNode[V]
+}
+
+type prefixNode[V any] struct {
+	children []*prefixNode[V] // sorted by the bit following the node prefix
+	addr     [16]byte         // masked prefix address, IPv4 addresses use the first 4 bytes
+	bits     int
+	value    V
+	ok       bool // node holds a stored prefix
+	parent   *prefixNode[V]
+}
+
+// NewPrefixTable creates a new IP prefix table
+func NewPrefixTable[V any]() (t *PrefixTable[V]) {
+	return &PrefixTable[V]{
+		v4: &prefixNode[V]{},
+		v6: &prefixNode[V]{},
+	}
+}
+
+// root returns the root for the address family and the address as 16 bytes
+func (t *PrefixTable[V]) root(addr netip.Addr) (n *prefixNode[V], a [16]byte) {
+	if addr.Is4() {
+		b := addr.As4()
+		copy(a[:], b[:])
+		return t.v4, a
+	}
+
+	return t.v6, addr.As16()
+}
+
+// prefix returns the node prefix
+func (n *prefixNode[V]) prefix(v4 bool) (p netip.Prefix) {
+	if v4 {
+		return netip.PrefixFrom(netip.AddrFrom4([4]byte{n.addr[0], n.addr[1], n.addr[2], n.addr[3]}), n.bits)
+	}
+	return netip.PrefixFrom(netip.AddrFrom16(n.addr), n.bits)
+}
+
+// bit returns the bit at position i of the address
+func bit(a *[16]byte, i int) (b byte) {
+	return (a[i/8] >> (7 - uint(i%8))) & 1
+}
+
+// commonBits returns the number of leading bits shared by both addresses, up to max
+func commonBits(a, b *[16]byte, max int) (l int) {
+	for x := 0; l < max; x++ {
+		if a[x] == b[x] {
+			l += 8
+			continue
+		}
+
+		diff := a[x] ^ b[x]
+		for diff&0x80 == 0 {
+			diff <<= 1
+			l++
+		}
+		break
+	}
+
+	if l > max {
+		return max
+	}
+	return l
+}
+
+// child returns the index of the child for the bit following the
+// node prefix in the address and whether such child exists
+func (n *prefixNode[V]) child(a *[16]byte) (i int, ok bool) {
+	b := bit(a, n.bits)
+	i = sort.Search(len(n.children), func(x int) bool {
+		return bit(&n.children[x].addr, n.bits) >= b
+	})
+
+	return i, i < len(n.children) && bit(&n.children[i].addr, n.bits) == b
+}
+
+// Insert sets or updates the value for the given prefix.
+// The prefix is masked and must be valid, otherwise ErrInvalidKey is returned.
+func (t *PrefixTable[V]) Insert(prefix netip.Prefix, value V) (err error) {
+	if !prefix.IsValid() {
+		return ErrInvalidKey
+	}
+
+	prefix = prefix.Masked()
+	n, addr := t.root(prefix.Addr())
+	bits := prefix.Bits()
+
+	for {
+		// existing prefix
+		if n.bits == bits {
+			if !n.ok {
+				t.size++
+			}
+			n.value, n.ok = value, true
+			return nil
+		}
+
+		// no child sharing the next bit, add the prefix as a leaf
+		i, ok := n.child(&addr)
+		if !ok {
+			leaf := &prefixNode[V]{addr: addr, bits: bits, value: value, ok: true, parent: n}
+			n.children = append(n.children, nil)
+			copy(n.children[i+1:], n.children[i:])
+			n.children[i] = leaf
+			t.size++
+			return nil
+		}
+
+		c := n.children[i]
+		common := commonBits(&c.addr, &addr, minInt(c.bits, bits))
+		if common == c.bits {
+			n = c
+			continue
+		}
+
+		// split the edge at the common prefix
+		m := &prefixNode[V]{addr: mask(addr, common), bits: common, parent: n}
+		n.children[i] = m
+		c.parent = m
+		m.children = []*prefixNode[V]{c}
+
+		if common == bits {
+			m.value, m.ok = value, true
+			t.size++
+			return nil
+		}
+
+		leaf := &prefixNode[V]{addr: addr, bits: bits, value: value, ok: true, parent: m}
+		m.children = append(m.children, leaf)
+		if bit(&addr, common) == 0 {
+			m.children[0], m.children[1] = m.children[1], m.children[0]
+		}
+
+		t.size++
+		return nil
+	}
+}
+
+func minInt(a, b int) (m int) {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// mask returns the address with all bits after the given number of bits cleared
+func mask(a [16]byte, bits int) (m [16]byte) {
+	copy(m[:bits/8], a[:bits/8])
+	if bits%8 != 0 {
+		m[bits/8] = a[bits/8] & ^byte(0xff>>uint(bits%8))
+	}
+	return m
+}
+
+// find returns the node for the given prefix if it exists
+func (t *PrefixTable[V]) find(prefix netip.Prefix) (n *prefixNode[V], ok bool) {
+	if !prefix.IsValid() {
+		return nil, false
+	}
+
+	prefix = prefix.Masked()
+	n, addr := t.root(prefix.Addr())
+	bits := prefix.Bits()
+
+	for n.bits < bits {
+		i, ok := n.child(&addr)
+		if !ok {
+			return nil, false
+		}
+
+		n = n.children[i]
+		if n.bits > bits || commonBits(&n.addr, &addr, n.bits) != n.bits {
+			return nil, false
+		}
+	}
+
+	return n, true
+}
+
+// Get retrieves the value for the exact given prefix
+func (t *PrefixTable[V]) Get(prefix netip.Prefix) (value V, ok bool) {
+	n, ok := t.find(prefix)
+	if !ok || !n.ok {
+		return value, false
+	}
+
+	return n.value, true
+}
+
+// Delete removes the given prefix. It returns ErrKeyNotFound if the prefix does not exist.
+func (t *PrefixTable[V]) Delete(prefix netip.Prefix) (err error) {
+	n, ok := t.find(prefix)
+	if !ok || !n.ok {
+		return ErrKeyNotFound
+	}
+
+	var zero V
+	n.value, n.ok = zero, false
+	t.size--
+
+	// remove or merge nodes left without a prefix, keeping the tree compressed
+	for n.parent != nil && !n.ok && len(n.children) < 2 {
+		p := n.parent
+		i, _ := p.child(&n.addr)
+
+		switch len(n.children) {
+		case 0:
+			p.children = append(p.children[:i], p.children[i+1:]...)
+		case 1:
+			p.children[i] = n.children[0]
+			n.children[0].parent = p
+		}
+
+		n = p
+	}
+
+	return nil
+}
+
+// Lookup returns the longest stored prefix containing the address and its value
+func (t *PrefixTable[V]) Lookup(addr netip.Addr) (prefix netip.Prefix, value V, ok bool) {
+	if !addr.IsValid() {
+		return prefix, value, false
+	}
+
+	n, a := t.root(addr)
+	bits := addr.BitLen()
+
+	var match *prefixNode[V]
+	for {
+		if n.ok {
+			match = n
+		}
+
+		if n.bits == bits {
+			break
+		}
+
+		i, ok := n.child(&a)
+		if !ok || commonBits(&n.children[i].addr, &a, n.children[i].bits) != n.children[i].bits {
+			break
+		}
+		n = n.children[i]
+	}
+
+	if match == nil {
+		return prefix, value, false
+	}
+
+	return match.prefix(addr.Is4()), match.value, true
+}
+
+// Contains checks if any stored prefix contains the address
+func (t *PrefixTable[V]) Contains(addr netip.Addr) (ok bool) {
+	_, _, ok = t.Lookup(addr)
+	return ok
+}
+
+// Supernets calls f for each stored prefix containing the given prefix, including
+// the prefix itself, from the shortest to the longest.
+// If f returns false, Supernets stops the iteration.
+func (t *PrefixTable[V]) Supernets(prefix netip.Prefix, f func(prefix netip.Prefix, value V) bool) {
+	if !prefix.IsValid() {
+		return
+	}
+
+	prefix = prefix.Masked()
+	n, addr := t.root(prefix.Addr())
+	v4 := prefix.Addr().Is4()
+	bits := prefix.Bits()
+
+	for {
+		if n.ok && !f(n.prefix(v4), n.value) {
+			return
+		}
+
+		if n.bits >= bits {
+			return
+		}
+
+		i, ok := n.child(&addr)
+		if !ok {
+			return
+		}
+
+		n = n.children[i]
+		if n.bits > bits || commonBits(&n.addr, &addr, n.bits) != n.bits {
+			return
+		}
+	}
+}
+
+// Subnets calls f for each stored prefix contained in the given prefix, including
+// the prefix itself, in address order. If f returns false, Subnets stops the iteration.
+func (t *PrefixTable[V]) Subnets(prefix netip.Prefix, f func(prefix netip.Prefix, value V) bool) {
+	if !prefix.IsValid() {
+		return
+	}
+
+	prefix = prefix.Masked()
+	n, addr := t.root(prefix.Addr())
+	bits := prefix.Bits()
+
+	// descend to the first node contained in the prefix
+	for n.bits < bits {
+		i, ok := n.child(&addr)
+		if !ok {
+			return
+		}
+
+		n = n.children[i]
+		if commonBits(&n.addr, &addr, minInt(n.bits, bits)) != minInt(n.bits, bits) {
+			return
+		}
+	}
+
+	n.iter(prefix.Addr().Is4(), f)
+}
+
+// Iter iterates all prefixes in address order, IPv4 before IPv6, with shorter
+// prefixes before the longer ones they contain. If f returns false, Iter stops the iteration.
+func (t *PrefixTable[V]) Iter(f func(prefix netip.Prefix, value V) bool) {
+	if t.v4.iter(true, f) {
+		t.v6.iter(false, f)
+	}
+}
+
+func (n *prefixNode[V]) iter(v4 bool, f func(prefix netip.Prefix, value V) bool) (ok bool) {
+	if n.ok && !f(n.prefix(v4), n.value) {
+		return false
+	}
+
+	for x := 0; x < len(n.children); x++ {
+		if !n.children[x].iter(v4, f) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Size returns the number of prefixes in the table
+func (t *PrefixTable[V]) Size() (sz uint64) {
+	return t.size
+}
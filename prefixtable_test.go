@@ -0,0 +1,143 @@
+package radixs
+
+import (
+	"math/rand"
+	"net/netip"
+	"testing"
+)
+
+func TestPrefixTable(t *testing.T) {
+	assert := newAssert(t)
+	pt := NewPrefixTable[string]()
+
+	prefixes := []string{"10.0.0.0/8", "10.0.0.0/20", "10.0.16.0/20", "10.0.0.0/27", "10.0.0.32/27", "0.0.0.0/0", "2001:db8::/32", "2001:db8:1::/48", "192.168.1.0/24"}
+	for _, s := range prefixes {
+		err := pt.Insert(netip.MustParsePrefix(s), s)
+		assert(err == nil, "error inserting prefix:", s, "err:", err)
+	}
+
+	err := pt.Insert(netip.MustParsePrefix("10.0.0.1/20"), "10.0.0.0/20")
+	assert(err == nil && pt.Size() == uint64(len(prefixes)), "unmasked prefix not updated, size:", pt.Size(), "err:", err)
+	assert(pt.Insert(netip.Prefix{}, "invalid") == ErrInvalidKey, "invalid prefix inserted")
+
+	tests := []struct {
+		addr  string
+		match string
+	}{
+		{"10.0.0.5", "10.0.0.0/27"},
+		{"10.0.0.33", "10.0.0.32/27"},
+		{"10.0.0.64", "10.0.0.0/20"},
+		{"10.0.17.1", "10.0.16.0/20"},
+		{"10.0.32.1", "10.0.0.0/8"},
+		{"11.0.0.1", "0.0.0.0/0"},
+		{"2001:db8:1::1", "2001:db8:1::/48"},
+		{"2001:db8:2::1", "2001:db8::/32"},
+		{"2001:db9::1", ""},
+	}
+
+	for _, tt := range tests {
+		p, v, ok := pt.Lookup(netip.MustParseAddr(tt.addr))
+		assert(ok == (tt.match != "") && v == tt.match, "addr:", tt.addr, "expected:", tt.match, "got:", v, p)
+		if ok {
+			assert(p.String() == tt.match, "addr:", tt.addr, "wrong prefix:", p)
+		}
+		assert(pt.Contains(netip.MustParseAddr(tt.addr)) == ok, "addr:", tt.addr, "wrong contains")
+	}
+
+	var found []string
+	pt.Supernets(netip.MustParsePrefix("10.0.0.0/27"), func(p netip.Prefix, v string) bool {
+		found = append(found, p.String())
+		return true
+	})
+	expected := []string{"0.0.0.0/0", "10.0.0.0/8", "10.0.0.0/20", "10.0.0.0/27"}
+	assert(equalKeys(found, expected), "expected supernets:", expected, "got:", found)
+
+	found = nil
+	pt.Subnets(netip.MustParsePrefix("10.0.0.0/19"), func(p netip.Prefix, v string) bool {
+		found = append(found, p.String())
+		return true
+	})
+	expected = []string{"10.0.0.0/20", "10.0.0.0/27", "10.0.0.32/27", "10.0.16.0/20"}
+	assert(equalKeys(found, expected), "expected subnets:", expected, "got:", found)
+
+	found = nil
+	pt.Iter(func(p netip.Prefix, v string) bool {
+		found = append(found, p.String())
+		return true
+	})
+	expected = []string{"0.0.0.0/0", "10.0.0.0/8", "10.0.0.0/20", "10.0.0.0/27", "10.0.0.32/27", "10.0.16.0/20", "192.168.1.0/24", "2001:db8::/32", "2001:db8:1::/48"}
+	assert(equalKeys(found, expected), "expected order:", expected, "got:", found)
+
+	err = pt.Delete(netip.MustParsePrefix("10.0.0.0/20"))
+	assert(err == nil, "error deleting prefix, err:", err)
+	_, v, _ := pt.Lookup(netip.MustParseAddr("10.0.0.64"))
+	assert(v == "10.0.0.0/8", "wrong match after delete:", v)
+
+	err = pt.Delete(netip.MustParsePrefix("10.0.0.0/21"))
+	assert(err == ErrKeyNotFound, "deleted missing prefix, err:", err)
+}
+
+func TestPrefixTableRandom(t *testing.T) {
+	assert := newAssert(t)
+	r := rand.New(rand.NewSource(1))
+	pt := NewPrefixTable[int]()
+	stored := map[netip.Prefix]int{}
+
+	randomPrefix := func() netip.Prefix {
+		// restrict the address space so prefixes overlap
+		if r.Intn(4) == 0 {
+			var a [16]byte
+			a[0], a[1], a[2] = 0x20, 0x01, byte(r.Intn(4))
+			return netip.PrefixFrom(netip.AddrFrom16(a), 8+r.Intn(40)).Masked()
+		}
+		a := [4]byte{10, byte(r.Intn(4)), byte(r.Intn(256)), byte(r.Intn(256))}
+		return netip.PrefixFrom(netip.AddrFrom4(a), r.Intn(33)).Masked()
+	}
+
+	for x := 0; x < 3000; x++ {
+		p := randomPrefix()
+		if r.Intn(3) == 0 {
+			_, ok := stored[p]
+			err := pt.Delete(p)
+			assert((err == nil) == ok, "prefix:", p, "stored:", ok, "delete err:", err)
+			delete(stored, p)
+			continue
+		}
+
+		_ = pt.Insert(p, x)
+		stored[p] = x
+	}
+
+	assert(pt.Size() == uint64(len(stored)), "expected size:", len(stored), "got:", pt.Size())
+
+	for x := 0; x < 2000; x++ {
+		addr := randomPrefix().Addr()
+
+		var best netip.Prefix
+		for p := range stored {
+			if p.Contains(addr) && (!best.IsValid() || p.Bits() > best.Bits()) {
+				best = p
+			}
+		}
+
+		p, v, ok := pt.Lookup(addr)
+		assert(ok == best.IsValid() && p == best, "addr:", addr, "expected:", best, "got:", p)
+		if ok {
+			assert(v == stored[best], "addr:", addr, "wrong value:", v)
+		}
+	}
+
+	var last netip.Prefix
+	var count int
+	pt.Iter(func(p netip.Prefix, v int) bool {
+		assert(stored[p] == v, "prefix:", p, "wrong value:", v)
+		if last.IsValid() && last.Addr().Is4() == p.Addr().Is4() {
+			c := last.Addr().Compare(p.Addr())
+			assert(c < 0 || (c == 0 && last.Bits() < p.Bits()), "prefixes not in address order:", last, p)
+		}
+		last = p
+		count++
+		return true
+	})
+	assert(count == len(stored), "expected iterated prefixes:", len(stored), "got:", count)
+}
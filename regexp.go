@@ -0,0 +1,206 @@
+package radixs
+
+import (
+	"regexp"
+	resyntax "regexp/syntax"
+	"unicode/utf8"
+)
+
+// regexpMatcher walks the tree simulating the regular expression program
+// over the walked path, pruning branches where no match is possible
+type regexpMatcher struct {
+	prog     *resyntax.Prog
+	anchored bool
+	visited  []uint32 // generation of the last closure visiting each instruction
+	gen      uint32
+	f        func(key string, value interface{}) bool
+}
+
+// regexpState is the regular expression program state after a path
+type regexpState struct {
+	pcs     []uint32 // instructions waiting for the next rune
+	prev    rune     // last rune, -1 at the beginning of the text
+	pending [utf8.UTFMax]byte
+	np      int  // bytes in pending, waiting to form a rune
+	matched bool // a match was found within the path, so all its extensions match
+}
+
+// RegexpSearch calls f for each key matched by the regular expression, as with re.MatchString,
+// in ascending lexicographic order. Expressions anchored at the beginning of the text
+// seek directly to the subtree of their literal prefix. Branches where no match is
+// possible are skipped and once a key prefix matches all the keys under it are matched.
+// In trees with case folding the expression is matched against the folded keys.
+// If f returns false, RegexpSearch stops the iteration.
+func (t *Tree) RegexpSearch(re *regexp.Regexp, f func(key string, value interface{}) bool) (err error) {
+	parsed, err := resyntax.Parse(re.String(), resyntax.Perl)
+	if err != nil {
+		return err
+	}
+
+	prog, err := resyntax.Compile(parsed.Simplify())
+	if err != nil {
+		return err
+	}
+
+	m := &regexpMatcher{
+		prog:     prog,
+		anchored: prog.StartCond()&resyntax.EmptyBeginText != 0,
+		visited:  make([]uint32, len(prog.Inst)),
+		f:        f,
+	}
+
+	n := t.root
+	path := ""
+	if prefix, _ := re.LiteralPrefix(); m.anchored && prefix != "" {
+		var ok bool
+		if n, ok = t.prefixNode(prefix); !ok {
+			return nil
+		}
+
+		if n.parent != nil {
+			path = n.parent.fullKey()
+		}
+	}
+
+	st := regexpState{pcs: []uint32{uint32(prog.Start)}, prev: -1}
+	for x := 0; x < len(path) && !m.dead(st); x++ {
+		st = m.feed(st, path[x])
+	}
+
+	if !m.dead(st) {
+		m.walk(n, path, st)
+	}
+
+	return nil
+}
+
+// walk walks the subtree of n, whose path from the root excluding n.key is prefix
+func (m *regexpMatcher) walk(n *node, prefix string, st regexpState) (ok bool) {
+	for x := 0; x < len(n.key); x++ {
+		if st = m.feed(st, n.key[x]); m.dead(st) {
+			return true
+		}
+
+		if st.matched {
+			return n.iter(prefix, m.f)
+		}
+	}
+
+	prefix += n.key
+	if n.value != nil && m.end(st) {
+		if !m.f(n.entry(prefix)) {
+			return false
+		}
+	}
+
+	for x := 0; x < len(n.children); x++ {
+		if !m.walk(n.children[x], prefix, st) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dead checks if no match is possible for any extension of the path
+func (m *regexpMatcher) dead(st regexpState) (ok bool) {
+	return m.anchored && !st.matched && len(st.pcs) == 0
+}
+
+// feed adds the byte c to the path, stepping the program once it forms a rune
+func (m *regexpMatcher) feed(st regexpState, c byte) (next regexpState) {
+	st.pending[st.np] = c
+	st.np++
+
+	for st.np > 0 && utf8.FullRune(st.pending[:st.np]) && !st.matched {
+		r, size := utf8.DecodeRune(st.pending[:st.np])
+		st = m.step(st, r)
+		st.np = copy(st.pending[:], st.pending[size:st.np])
+	}
+
+	return st
+}
+
+// end checks if the path is matched at the end of the text
+func (m *regexpMatcher) end(st regexpState) (ok bool) {
+	// incomplete runes at the end of the text are invalid
+	for st.np > 0 && !st.matched {
+		r, size := utf8.DecodeRune(st.pending[:st.np])
+		st = m.step(st, r)
+		st.np = copy(st.pending[:], st.pending[size:st.np])
+	}
+
+	if st.matched {
+		return true
+	}
+
+	for _, pc := range m.closure(st, -1) {
+		if m.prog.Inst[pc].Op == resyntax.InstMatch {
+			return true
+		}
+	}
+
+	return false
+}
+
+// step advances the program over the rune r
+func (m *regexpMatcher) step(st regexpState, r rune) (next regexpState) {
+	next = st
+	next.prev = r
+	next.pcs = nil
+
+	for _, pc := range m.closure(st, r) {
+		inst := &m.prog.Inst[pc]
+		switch {
+		case inst.Op == resyntax.InstMatch:
+			next.matched = true
+			return next
+		case inst.MatchRune(r):
+			next.pcs = append(next.pcs, inst.Out)
+		}
+	}
+
+	return next
+}
+
+// closure returns the rune consuming and match instructions reachable from
+// the state instructions, given the rune r following the path
+func (m *regexpMatcher) closure(st regexpState, r rune) (pcs []uint32) {
+	m.gen++
+	cond := resyntax.EmptyOpContext(st.prev, r)
+
+	var follow func(pc uint32)
+	follow = func(pc uint32) {
+		if m.visited[pc] == m.gen {
+			return
+		}
+		m.visited[pc] = m.gen
+
+		inst := &m.prog.Inst[pc]
+		switch inst.Op {
+		case resyntax.InstAlt, resyntax.InstAltMatch:
+			follow(inst.Out)
+			follow(inst.Arg)
+		case resyntax.InstCapture, resyntax.InstNop:
+			follow(inst.Out)
+		case resyntax.InstEmptyWidth:
+			if resyntax.EmptyOp(inst.Arg)&^cond == 0 {
+				follow(inst.Out)
+			}
+		case resyntax.InstFail:
+		default:
+			pcs = append(pcs, pc)
+		}
+	}
+
+	for x := 0; x < len(st.pcs); x++ {
+		follow(st.pcs[x])
+	}
+
+	// unanchored expressions may start a match at any position
+	if !m.anchored {
+		follow(uint32(m.prog.Start))
+	}
+
+	return pcs
+}
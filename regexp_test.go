@@ -0,0 +1,64 @@
+package radixs
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRegexpSearch(t *testing.T) {
+	assert := newAssert(t)
+	keyCount := 20000
+	tr := New()
+
+	for x := 0; x < keyCount; x++ {
+		key := generateUUID()
+		err := tr.Set(key, x)
+		assert(err == nil, "failed to set key:", key, "err", err)
+	}
+
+	for k, v := range pairs {
+		_ = tr.Set(k, v)
+	}
+	_ = tr.Set("héllo wörld", "unicode")
+	_ = tr.Set("h\xffllo", "invalid")
+
+	expressions := []string{
+		`^a`, `^ab`, `^abc.*f$`, `^[0-9a-f]{2}0`, `ff$`, `^[a-f]+-`, `0000`, `-a[0-9]b`,
+		`^rom`, `^rom(an|ul)`, `^r.*s$`, `ubi`, `^(?i)ROM`, `^\w+$`, `\bcon`, `^.{5}$`,
+		`^h.llo`, `ö`, `^h\x{fffd}llo$`, `^$`, ``, `^x`, `e$|^s`, `(?m)^sm`,
+	}
+
+	for _, expr := range expressions {
+		re := regexp.MustCompile(expr)
+
+		var expected []string
+		tr.Iter(func(key string, value interface{}) bool {
+			if re.MatchString(key) {
+				expected = append(expected, key)
+			}
+			return true
+		})
+
+		var found []string
+		err := tr.RegexpSearch(re, func(key string, value interface{}) bool {
+			found = append(found, key)
+			return true
+		})
+
+		assert(err == nil, "expression:", expr, "error:", err)
+		assert(len(found) == len(expected), "expression:", expr, "expected:", len(expected), "got:", len(found))
+		for x := 0; x < len(found) && x < len(expected); x++ {
+			if found[x] != expected[x] {
+				assert(false, "expression:", expr, "expected:", expected[x], "got:", found[x])
+				break
+			}
+		}
+	}
+
+	var count int
+	_ = tr.RegexpSearch(regexp.MustCompile(`a`), func(key string, value interface{}) bool {
+		count++
+		return count < 3
+	})
+	assert(count == 3, "iteration not stopped, count:", count)
+}
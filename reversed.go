@@ -0,0 +1,129 @@
+package radixs
+
+// ReversedTree is a Tree whose keys are transparently reversed on input and output,
+// for suffix matching like hostnames. Keys are reversed byte by byte, or label
+// by label on the given delimiter, so api.eu.example.com is stored as com.example.eu.api.
+type ReversedTree struct {
+	tree  *Tree
+	label byte
+}
+
+// NewReversed creates a new reversed radix tree with the given options. If label is not 0,
+// keys are reversed at label granularity using label as delimiter, otherwise byte by byte.
+func NewReversed(label byte, opts ...OptFunc) (r *ReversedTree) {
+	return &ReversedTree{tree: New(opts...), label: label}
+}
+
+// reverse reverses the key bytes or labels. It is its own inverse.
+func (r *ReversedTree) reverse(key string) (reversed string) {
+	b := make([]byte, len(key))
+	if r.label == 0 {
+		for x := 0; x < len(key); x++ {
+			b[len(key)-1-x] = key[x]
+		}
+		return string(b)
+	}
+
+	// copy each label to its mirrored position, keeping the delimiters between them
+	start := 0
+	for x := 0; x <= len(key); x++ {
+		if x < len(key) && key[x] != r.label {
+			continue
+		}
+
+		copy(b[len(key)-x:], key[start:x])
+		if x < len(key) {
+			b[len(key)-1-x] = r.label
+		}
+		start = x + 1
+	}
+
+	return string(b)
+}
+
+// Set or update the value for the given key
+func (r *ReversedTree) Set(key string, value interface{}) (err error) {
+	return r.tree.Set(r.reverse(key), value)
+}
+
+// Get retrieves the value for the given key
+func (r *ReversedTree) Get(key string) (value interface{}, err error) {
+	return r.tree.Get(r.reverse(key))
+}
+
+// Delete deletes the given key
+func (r *ReversedTree) Delete(key string) (err error) {
+	return r.tree.Delete(r.reverse(key))
+}
+
+// LongestMatch returns the stored key that is the longest suffix of the given key.
+// With label granularity only whole labels are matched, so example.com matches
+// api.example.com but not myexample.com. Keys whose first label is the tree wildcard
+// placeholder, set with WithWildcard, match one or more labels in its place and take
+// precedence over the same key without it, e.g. *.example.com matches api.example.com
+// before example.com does, but does not match example.com.
+func (r *ReversedTree) LongestMatch(key string) (match string, value interface{}, err error) {
+	if r.label == 0 {
+		match, value, err = r.tree.LongestMatch(r.reverse(key))
+		if err != nil {
+			return "", nil, err
+		}
+		return r.reverse(match), value, nil
+	}
+
+	if key == "" {
+		return "", nil, ErrEmptyKey
+	}
+
+	reversed := r.reverse(key)
+	folded := r.tree.foldKey(reversed)
+
+	// candidates are ranked by their matched length, wildcards
+	// ranking above the key they are the wildcard for
+	rank := -1
+	var best *node
+
+	r.tree.prefixes(folded, func(n *node, depth int) bool {
+		if depth == len(folded) || folded[depth] == r.label {
+			rank, best = 2*depth, n
+			match = r.tree.originalRange(reversed, 0, depth)
+		}
+		return true
+	})
+
+	if r.tree.wildcard != 0 {
+		wildcard := string([]byte{r.label, r.tree.wildcard})
+		for x := 0; x < len(folded); x++ {
+			if folded[x] != r.label || 2*x+1 <= rank {
+				continue
+			}
+
+			// in trees with case folding the registered key is returned by entry
+			if n, ok := r.tree.get(folded[:x] + wildcard); ok {
+				rank, best = 2*x+1, n
+				match = folded[:x] + wildcard
+			}
+		}
+	}
+
+	if best == nil {
+		return "", nil, ErrKeyNotFound
+	}
+
+	match, value = best.entry(match)
+	return r.reverse(match), value, nil
+}
+
+// Iter iterates the tree calling f with the keys in their original order,
+// in ascending lexicographic order of the reversed keys.
+// If f returns false, Iter stops the iteration.
+func (r *ReversedTree) Iter(f func(key string, value interface{}) bool) {
+	r.tree.Iter(func(key string, value interface{}) bool {
+		return f(r.reverse(key), value)
+	})
+}
+
+// Size returns the number of keys in the tree
+func (r *ReversedTree) Size() (sz uint64) {
+	return r.tree.Size()
+}
@@ -0,0 +1,74 @@
+package radixs
+
+import (
+	"testing"
+)
+
+func TestReversedTree(t *testing.T) {
+	assert := newAssert(t)
+	r := NewReversed(0)
+
+	for _, k := range []string{"example.com", "le.com", "test.org"} {
+		err := r.Set(k, k)
+		assert(err == nil, "error setting key:", k, "err:", err)
+	}
+
+	value, err := r.Get("example.com")
+	assert(err == nil && value == "example.com", "wrong value:", value, "err:", err)
+
+	match, value, err := r.LongestMatch("www.example.com")
+	assert(err == nil && match == "example.com" && value == "example.com", "wrong match:", match, value, "err:", err)
+
+	match, _, err = r.LongestMatch("myle.com")
+	assert(err == nil && match == "le.com", "wrong byte level match:", match, "err:", err)
+
+	var keys []string
+	r.Iter(func(key string, value interface{}) bool {
+		keys = append(keys, key)
+		return true
+	})
+	expected := []string{"test.org", "le.com", "example.com"}
+	assert(equalKeys(keys, expected), "expected:", expected, "got:", keys)
+
+	err = r.Delete("le.com")
+	assert(err == nil && r.Size() == 2, "error deleting key, size:", r.Size(), "err:", err)
+}
+
+func TestReversedTreeLabels(t *testing.T) {
+	assert := newAssert(t)
+	r := NewReversed('.', WithWildcard('*'), WithCaseFolding())
+
+	for _, k := range []string{"example.com", "*.Example.com", "api.eu.example.com", "le.com", "*.eu.example.com"} {
+		err := r.Set(k, k)
+		assert(err == nil, "error setting key:", k, "err:", err)
+	}
+
+	assert(r.reverse("api.eu.example.com") == "com.example.eu.api", "wrong reversed labels:", r.reverse("api.eu.example.com"))
+	assert(r.reverse("a..b.") == ".b..a", "wrong reversed empty labels:", r.reverse("a..b."))
+
+	tests := []struct {
+		key   string
+		match string
+		err   error
+	}{
+		{"example.com", "example.com", nil},
+		{"EXAMPLE.com", "example.com", nil},
+		{"www.example.com", "*.Example.com", nil},
+		{"a.b.example.com", "*.Example.com", nil},
+		{"eu.example.com", "*.Example.com", nil},
+		{"api.eu.example.com", "api.eu.example.com", nil},
+		{"www.eu.example.com", "*.eu.example.com", nil},
+		{"myexample.com", "", ErrKeyNotFound},
+		{"ale.com", "", ErrKeyNotFound},
+		{"le.com", "le.com", nil},
+		{"", "", ErrEmptyKey},
+	}
+
+	for _, tt := range tests {
+		match, value, err := r.LongestMatch(tt.key)
+		assert(err == tt.err && match == tt.match, "key:", tt.key, "expected:", tt.match, tt.err, "got:", match, err)
+		if err == nil {
+			assert(value == tt.match, "key:", tt.key, "wrong value:", value)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+// Package router implements a net/http request router on top of radixs.Tree
+// parameter matching. Each HTTP method is backed by its own parameterized tree
+// using '/' as the key delimiter and ':' as the parameter placeholder.
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/brunotm/radixs"
+)
+
+var (
+	ErrInvalidMethod  = fmt.Errorf("router: invalid method")
+	ErrInvalidPattern = fmt.Errorf("router: pattern must begin with '/'")
+	ErrNilHandler     = fmt.Errorf("router: handler cannot be nil")
+)
+
+const (
+	delimiter = '/'
+	parameter = ':'
+)
+
+// Middleware wraps a http.Handler with additional behavior
+type Middleware func(next http.Handler) http.Handler
+
+// Router is a http.Handler which dispatches requests to the handlers
+// registered for the request method and path
+type Router struct {
+	trees                 map[string]*radixs.Tree
+	middlewares           []Middleware
+	handler               http.Handler
+	notFound              http.Handler
+	methodNotAllowed      http.Handler
+	redirectTrailingSlash bool
+	redirectCleanPath     bool
+	handleOptions         bool
+}
+
+// OptFunc functional options for router creation
+type OptFunc func(r *Router)
+
+// WithNotFound sets the handler called when no route matches the request path.
+// Defaults to http.NotFoundHandler.
+func WithNotFound(h http.Handler) (opt OptFunc) {
+	return func(r *Router) {
+		r.notFound = h
+	}
+}
+
+// WithMethodNotAllowed sets the handler called when the request path matches
+// a route registered for other methods. The Allow header is set before it is called.
+func WithMethodNotAllowed(h http.Handler) (opt OptFunc) {
+	return func(r *Router) {
+		r.methodNotAllowed = h
+	}
+}
+
+// WithRedirectTrailingSlash enables or disables redirecting requests
+// that only match a route with the trailing slash added or removed. Enabled by default.
+func WithRedirectTrailingSlash(enabled bool) (opt OptFunc) {
+	return func(r *Router) {
+		r.redirectTrailingSlash = enabled
+	}
+}
+
+// WithRedirectCleanPath enables or disables redirecting requests whose
+// cleaned path (as in path.Clean) matches a route. Enabled by default.
+func WithRedirectCleanPath(enabled bool) (opt OptFunc) {
+	return func(r *Router) {
+		r.redirectCleanPath = enabled
+	}
+}
+
+// WithAutoOptions enables or disables automatic replies to OPTIONS requests
+// for paths without an explicit OPTIONS handler. Enabled by default.
+func WithAutoOptions(enabled bool) (opt OptFunc) {
+	return func(r *Router) {
+		r.handleOptions = enabled
+	}
+}
+
+// New creates a new router
+func New(opts ...OptFunc) (r *Router) {
+	r = &Router{
+		trees:                 map[string]*radixs.Tree{},
+		notFound:              http.NotFoundHandler(),
+		methodNotAllowed:      http.HandlerFunc(methodNotAllowed),
+		redirectTrailingSlash: true,
+		redirectCleanPath:     true,
+		handleOptions:         true,
+	}
+
+	for x := 0; x < len(opts); x++ {
+		opts[x](r)
+	}
+
+	r.handler = http.HandlerFunc(r.serve)
+	return r
+}
+
+// Handle registers the handler for the given method and pattern.
+// Patterns use '/' as delimiter and ':' as parameter placeholder, e.g. /users/:id.
+func (r *Router) Handle(method, pattern string, h http.Handler) (err error) {
+	if method == "" || strings.ContainsAny(method, " \t\r\n") {
+		return ErrInvalidMethod
+	}
+
+	if pattern == "" || pattern[0] != delimiter {
+		return ErrInvalidPattern
+	}
+
+	if h == nil {
+		return ErrNilHandler
+	}
+
+	t, ok := r.trees[method]
+	if !ok {
+		t = radixs.New(radixs.WithParams(delimiter, parameter))
+		r.trees[method] = t
+	}
+
+	return t.SetWithParams(pattern, h)
+}
+
+// HandleFunc is like Handle but takes a handler function
+func (r *Router) HandleFunc(method, pattern string, f func(http.ResponseWriter, *http.Request)) (err error) {
+	if f == nil {
+		return ErrNilHandler
+	}
+	return r.Handle(method, pattern, http.HandlerFunc(f))
+}
+
+// Use appends middlewares to the router chain. Middlewares are applied
+// in the order they were added, the first one being the outermost,
+// and wrap every request including not found and method not allowed replies.
+func (r *Router) Use(mws ...Middleware) {
+	r.middlewares = append(r.middlewares, mws...)
+
+	r.handler = http.HandlerFunc(r.serve)
+	for x := len(r.middlewares) - 1; x >= 0; x-- {
+		r.handler = r.middlewares[x](r.handler)
+	}
+}
+
+// ServeHTTP dispatches the request to the handler whose pattern matches the request path
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.handler.ServeHTTP(w, req)
+}
+
+func (r *Router) serve(w http.ResponseWriter, req *http.Request) {
+	p := req.URL.Path
+
+	if h, params, ok := r.lookup(req.Method, p); ok {
+		h.ServeHTTP(w, withParams(req, params))
+		return
+	}
+
+	// automatic HEAD replies using the GET handler
+	if req.Method == http.MethodHead {
+		if h, params, ok := r.lookup(http.MethodGet, p); ok {
+			h.ServeHTTP(w, withParams(req, params))
+			return
+		}
+	}
+
+	if req.Method != http.MethodConnect && p != "/" {
+		if target, ok := r.redirectPath(req.Method, p); ok {
+			code := http.StatusMovedPermanently
+			if req.Method != http.MethodGet && req.Method != http.MethodHead {
+				code = http.StatusPermanentRedirect
+			}
+
+			u := *req.URL
+			u.Path = target
+			http.Redirect(w, req, u.String(), code)
+			return
+		}
+	}
+
+	allow := r.allowed(p)
+	if len(allow) == 0 {
+		r.notFound.ServeHTTP(w, req)
+		return
+	}
+
+	w.Header().Set("Allow", strings.Join(allow, ", "))
+	if req.Method == http.MethodOptions && r.handleOptions {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	r.methodNotAllowed.ServeHTTP(w, req)
+}
+
+// lookup finds the handler and parameters for the given method and path
+func (r *Router) lookup(method, p string) (h http.Handler, params map[string]string, ok bool) {
+	t, ok := r.trees[method]
+	if !ok {
+		return nil, nil, false
+	}
+
+	params = map[string]string{}
+	value, err := t.GetWithParams(p, params)
+	if err != nil {
+		return nil, nil, false
+	}
+
+	return value.(http.Handler), params, true
+}
+
+// redirectPath returns the path the request should be redirected to
+// if the cleaned path or the path with a trailing slash added or removed matches a route
+func (r *Router) redirectPath(method, p string) (target string, ok bool) {
+	if r.redirectCleanPath {
+		clean := path.Clean(p)
+		if strings.HasSuffix(p, "/") && clean != "/" {
+			clean += "/"
+		}
+
+		if clean != p {
+			if r.matches(method, clean) {
+				return clean, true
+			}
+			p = clean
+		}
+	}
+
+	if r.redirectTrailingSlash {
+		target = p + "/"
+		if strings.HasSuffix(p, "/") {
+			target = p[:len(p)-1]
+		}
+
+		if r.matches(method, target) {
+			return target, true
+		}
+	}
+
+	return "", false
+}
+
+// matches reports if a route exists for the given method and path
+func (r *Router) matches(method, p string) (ok bool) {
+	if _, _, ok = r.lookup(method, p); ok {
+		return true
+	}
+
+	if method == http.MethodHead {
+		_, _, ok = r.lookup(http.MethodGet, p)
+	}
+
+	return ok
+}
+
+// allowed returns the sorted list of methods with a route matching the given path.
+// The path "*" matches every registered method.
+func (r *Router) allowed(p string) (methods []string) {
+	for method := range r.trees {
+		if p == "*" || r.matches(method, p) {
+			methods = append(methods, method)
+		}
+	}
+
+	if len(methods) == 0 {
+		return nil
+	}
+
+	if r.handleOptions && !contains(methods, http.MethodOptions) {
+		methods = append(methods, http.MethodOptions)
+	}
+
+	if contains(methods, http.MethodGet) && !contains(methods, http.MethodHead) {
+		methods = append(methods, http.MethodHead)
+	}
+
+	sort.Strings(methods)
+	return methods
+}
+
+func contains(s []string, v string) (ok bool) {
+	for x := 0; x < len(s); x++ {
+		if s[x] == v {
+			return true
+		}
+	}
+	return false
+}
+
+func methodNotAllowed(w http.ResponseWriter, _ *http.Request) {
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
+
+type paramsKey struct{}
+
+func withParams(req *http.Request, params map[string]string) (r *http.Request) {
+	if len(params) == 0 {
+		return req
+	}
+	return req.WithContext(context.WithValue(req.Context(), paramsKey{}, params))
+}
+
+// Params returns the path parameters stored in the request context,
+// with typed accessors like Int and UUID for their values.
+// It returns nil if the matched route has no parameters.
+func Params(ctx context.Context) (params radixs.Params) {
+	params, _ = ctx.Value(paramsKey{}).(map[string]string)
+	return params
+}
+
+// Param returns the value of the named path parameter stored in the request context
+func Param(ctx context.Context, name string) (value string) {
+	return Params(ctx)[name]
+}
@@ -0,0 +1,252 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newAssert(t testing.TB) func(cond bool, kvs ...interface{}) {
+	return func(cond bool, args ...interface{}) {
+		if !cond {
+			t.Helper()
+			t.Error(args...)
+		}
+	}
+}
+
+func reply(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body + " " + Param(r.Context(), "user") + Param(r.Context(), "repo")))
+	}
+}
+
+func newTestRouter(t *testing.T, opts ...OptFunc) (r *Router) {
+	assert := newAssert(t)
+	r = New(opts...)
+
+	routes := []struct {
+		method  string
+		pattern string
+	}{
+		{http.MethodGet, "/users"},
+		{http.MethodPost, "/users"},
+		{http.MethodGet, "/users/:user"},
+		{http.MethodDelete, "/users/:user"},
+		{http.MethodGet, "/users/:user/repos/:repo"},
+		{http.MethodGet, "/docs/"},
+	}
+
+	for _, rt := range routes {
+		err := r.Handle(rt.method, rt.pattern, reply(rt.method+" "+rt.pattern))
+		assert(err == nil, "error registering:", rt.method, rt.pattern, "err:", err)
+	}
+
+	return r
+}
+
+func serve(r http.Handler, method, target string) (w *httptest.ResponseRecorder) {
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+	return w
+}
+
+func TestRouterDispatch(t *testing.T) {
+	assert := newAssert(t)
+	r := newTestRouter(t)
+
+	tests := []struct {
+		method string
+		target string
+		body   string
+	}{
+		{http.MethodGet, "/users", "GET /users "},
+		{http.MethodPost, "/users", "POST /users "},
+		{http.MethodGet, "/users/bob", "GET /users/:user bob"},
+		{http.MethodDelete, "/users/bob", "DELETE /users/:user bob"},
+		{http.MethodGet, "/users/bob/repos/radixs", "GET /users/:user/repos/:repo bobradixs"},
+		{http.MethodGet, "/docs/", "GET /docs/ "},
+	}
+
+	for _, tt := range tests {
+		w := serve(r, tt.method, tt.target)
+		assert(w.Code == http.StatusOK, tt.method, tt.target, "expected status:", http.StatusOK, "got:", w.Code)
+		assert(w.Body.String() == tt.body, tt.method, tt.target, "expected body:", tt.body, "got:", w.Body.String())
+	}
+}
+
+func TestRouterPrefixRoutes(t *testing.T) {
+	assert := newAssert(t)
+
+	// routes where one pattern is a prefix of another
+	tests := [][]string{
+		{"/v1", "/v1/v1"},
+		{"/ab", "/a/a"},
+		{"/users/:user", "/users/:user/users"},
+	}
+
+	for _, patterns := range tests {
+		r := New()
+		for _, pattern := range patterns {
+			err := r.Handle(http.MethodGet, pattern, reply(pattern))
+			assert(err == nil, "error registering:", pattern, "err:", err)
+		}
+
+		for _, pattern := range patterns {
+			target := strings.Replace(pattern, ":user", "bob", 1)
+			w := serve(r, http.MethodGet, target)
+			assert(w.Code == http.StatusOK, target, "expected status:", http.StatusOK, "got:", w.Code)
+			assert(strings.HasPrefix(w.Body.String(), pattern+" "), target, "expected handler:", pattern, "got:", w.Body.String())
+		}
+	}
+}
+
+func TestRouterNotFoundAndMethodNotAllowed(t *testing.T) {
+	assert := newAssert(t)
+	r := newTestRouter(t)
+
+	w := serve(r, http.MethodGet, "/projects")
+	assert(w.Code == http.StatusNotFound, "expected status:", http.StatusNotFound, "got:", w.Code)
+
+	w = serve(r, http.MethodGet, "/users/bob/repos")
+	assert(w.Code == http.StatusNotFound, "expected status:", http.StatusNotFound, "got:", w.Code)
+
+	w = serve(r, http.MethodPut, "/users/bob")
+	assert(w.Code == http.StatusMethodNotAllowed, "expected status:", http.StatusMethodNotAllowed, "got:", w.Code)
+	allow := "DELETE, GET, HEAD, OPTIONS"
+	assert(w.Header().Get("Allow") == allow, "expected allow:", allow, "got:", w.Header().Get("Allow"))
+
+	called := false
+	r = newTestRouter(t,
+		WithNotFound(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})),
+		WithMethodNotAllowed(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		})),
+	)
+
+	w = serve(r, http.MethodGet, "/projects")
+	assert(w.Code == http.StatusTeapot, "expected custom not found status:", http.StatusTeapot, "got:", w.Code)
+
+	w = serve(r, http.MethodPatch, "/users")
+	assert(called, "custom method not allowed handler not called")
+	allow = "GET, HEAD, OPTIONS, POST"
+	assert(w.Header().Get("Allow") == allow, "expected allow:", allow, "got:", w.Header().Get("Allow"))
+}
+
+func TestRouterHeadAndOptions(t *testing.T) {
+	assert := newAssert(t)
+	r := newTestRouter(t)
+
+	w := serve(r, http.MethodHead, "/users/bob")
+	assert(w.Code == http.StatusOK, "expected status:", http.StatusOK, "got:", w.Code)
+
+	w = serve(r, http.MethodOptions, "/users/bob")
+	assert(w.Code == http.StatusNoContent, "expected status:", http.StatusNoContent, "got:", w.Code)
+	allow := "DELETE, GET, HEAD, OPTIONS"
+	assert(w.Header().Get("Allow") == allow, "expected allow:", allow, "got:", w.Header().Get("Allow"))
+
+	w = serve(r, http.MethodOptions, "*")
+	allow = "DELETE, GET, HEAD, OPTIONS, POST"
+	assert(w.Code == http.StatusNoContent, "expected status:", http.StatusNoContent, "got:", w.Code)
+	assert(w.Header().Get("Allow") == allow, "expected allow:", allow, "got:", w.Header().Get("Allow"))
+
+	err := r.HandleFunc(http.MethodOptions, "/users", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	assert(err == nil, "error registering options handler:", err)
+
+	w = serve(r, http.MethodOptions, "/users")
+	assert(w.Code == http.StatusAccepted, "expected explicit options status:", http.StatusAccepted, "got:", w.Code)
+
+	r = newTestRouter(t, WithAutoOptions(false))
+	w = serve(r, http.MethodOptions, "/users/bob")
+	assert(w.Code == http.StatusMethodNotAllowed, "expected status:", http.StatusMethodNotAllowed, "got:", w.Code)
+}
+
+func TestRouterRedirects(t *testing.T) {
+	assert := newAssert(t)
+	r := newTestRouter(t)
+
+	tests := []struct {
+		method   string
+		target   string
+		code     int
+		location string
+	}{
+		{http.MethodGet, "/users/", http.StatusMovedPermanently, "/users"},
+		{http.MethodGet, "/docs", http.StatusMovedPermanently, "/docs/"},
+		{http.MethodPost, "/users/", http.StatusPermanentRedirect, "/users"},
+		{http.MethodGet, "/users/../users/bob", http.StatusMovedPermanently, "/users/bob"},
+		{http.MethodGet, "/users//bob?tab=repos", http.StatusMovedPermanently, "/users/bob?tab=repos"},
+		{http.MethodGet, "/users/./bob/", http.StatusMovedPermanently, "/users/bob"},
+	}
+
+	for _, tt := range tests {
+		w := serve(r, tt.method, tt.target)
+		assert(w.Code == tt.code, tt.method, tt.target, "expected status:", tt.code, "got:", w.Code)
+		assert(w.Header().Get("Location") == tt.location,
+			tt.method, tt.target, "expected location:", tt.location, "got:", w.Header().Get("Location"))
+	}
+
+	r = newTestRouter(t, WithRedirectTrailingSlash(false), WithRedirectCleanPath(false))
+	w := serve(r, http.MethodGet, "/users/")
+	assert(w.Code == http.StatusNotFound, "expected status:", http.StatusNotFound, "got:", w.Code)
+
+	w = serve(r, http.MethodGet, "/users/../users/bob")
+	assert(w.Code == http.StatusNotFound, "expected status:", http.StatusNotFound, "got:", w.Code)
+}
+
+func TestRouterMiddleware(t *testing.T) {
+	assert := newAssert(t)
+	r := newTestRouter(t)
+
+	var trace []string
+	mw := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				trace = append(trace, name)
+				next.ServeHTTP(w, req)
+			})
+		}
+	}
+
+	r.Use(mw("first"), mw("second"))
+	r.Use(mw("third"))
+
+	w := serve(r, http.MethodGet, "/users/bob")
+	assert(w.Code == http.StatusOK, "expected status:", http.StatusOK, "got:", w.Code)
+	assert(strings.Join(trace, ",") == "first,second,third", "wrong middleware order:", trace)
+
+	trace = nil
+	w = serve(r, http.MethodGet, "/projects")
+	assert(w.Code == http.StatusNotFound, "expected status:", http.StatusNotFound, "got:", w.Code)
+	assert(len(trace) == 3, "middlewares not applied to not found replies:", trace)
+}
+
+func TestRouterHandleErrors(t *testing.T) {
+	assert := newAssert(t)
+	r := newTestRouter(t)
+
+	err := r.Handle("", "/users", reply(""))
+	assert(err == ErrInvalidMethod, "expected invalid method, got:", err)
+
+	err = r.Handle(http.MethodGet, "users", reply(""))
+	assert(err == ErrInvalidPattern, "expected invalid pattern, got:", err)
+
+	err = r.Handle(http.MethodGet, "/users", nil)
+	assert(err == ErrNilHandler, "expected nil handler, got:", err)
+
+	err = r.Handle(http.MethodGet, "/users/:name", reply(""))
+	assert(err != nil, "expected conflicting pattern error")
+}
+
+func TestParamsWithoutRoute(t *testing.T) {
+	assert := newAssert(t)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert(Params(req.Context()) == nil, "expected nil params")
+	assert(Param(req.Context(), "user") == "", "expected empty param")
+}
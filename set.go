@@ -15,31 +15,55 @@ func (t *Tree) set(key string, value interface{}, params bool) (err error) {
 		return ErrNilValue
 	}
 
+	var n *node
 	if params {
-		// scan key and check for invalid constructs with delimiters and parameters
-		for x := 0; x < len(key); x++ {
-			// delim followed by delim
-			if key[x] == t.delimiter && key[x+1] == t.delimiter {
-				return ErrInvalidKey
-			}
+		if err = t.validateKey(key); err != nil {
+			return err
+		}
+
+		if n, err = t.conflict(key, value); err != nil {
+			return err
+		}
+	}
 
-			// param followed by delim or delim
-			if key[x] == t.parameter && (key[x+1] == t.delimiter || key[x+1] == t.parameter) {
-				return ErrInvalidKey
+	// new keys are inserted, existing ones were already found by the conflict check
+	if n == nil {
+		n = t.insert(key)
+	}
+
+	// keys folding to the same value of an existing key conflict
+	if t.folding != foldNone {
+		if v, ok := value.(*folded); ok {
+			if err = foldConflict(n, v.key); err != nil {
+				return err
 			}
 		}
 	}
 
-	n := t.root
+	// setting a new key or an existing prefix increase tree size
+	if n.value == nil {
+		t.size++
+	}
+
+	// trees without posting lists skip looking into the replaced value
+	if t.postings > 0 {
+		t.postings -= uint64(postingsLen(n.value))
+	}
+	t.postings += uint64(postingsLen(value))
+	n.value = value
+	t.updateMax(n)
+
+	return nil
+}
+
+// insert returns the node for the given key, creating it without
+// a value and splitting existing nodes if it does not exist
+func (t *Tree) insert(key string) (n *node) {
+	n = t.root
 	for {
-		// existing key, update its value
+		// existing key
 		if n.key == key {
-			// setting an existing prefix increase tree size
-			if n.value == nil {
-				t.size++
-			}
-			n.value = value
-			return nil
+			return n
 		}
 
 		// obtain the longest common prefix for the current search key
@@ -49,81 +73,47 @@ func (t *Tree) set(key string, value interface{}, params bool) (err error) {
 		// insert and add existing node as child
 		if pi > 0 && len(n.key) > pi {
 			// pnode for updating children parent after split
-			var pnode *node
+			pnode := &node{
+				key:      n.key[pi:],
+				value:    n.value,
+				parent:   n,
+				children: n.children,
+			}
+
+			// update split node children parent
+			for x := 0; x < len(pnode.children); x++ {
+				pnode.children[x].parent = pnode
+			}
+
+			// the split node subtree keeps the same maximum count
+			if t.counting {
+				t.setSubtreeMax(pnode, t.subtreeMax(n))
+			}
+
+			n.key = n.key[:pi]
+			n.value = nil
 
 			// common prefix is full search key segment
 			// split and add current node as a child
 			if pi == len(key) {
-				pnode = &node{
-					key:      n.key[pi:],
-					value:    n.value,
-					parent:   n,
-					children: n.children,
-				}
-
 				n.children = []*node{pnode}
-				n.key = key
-				n.value = value
-			} else {
-				// key segment shares a common prefix with the current node
-				// split at the common prefix and add children nodes
-
-				parentK := n.key[:pi]
-				childK1 := n.key[pi:]
-				childK2 := key[pi:]
-
-				// if working with parameters and the current node key at split is not
-				// an exact prefix of the current key segment we have an invalid key
-				if params {
-					nIdx := strings.IndexByte(childK1, t.parameter)
-					sIdx := strings.IndexByte(childK2, t.parameter)
-
-					// check if we have a conflicting parameter in either the search key or node key
-					// by verifying that n.key[pi:] is a prefix of key[pi:] if:
-					// we have a parameter placeholder in the search or node key after the common prefix at index 0 or 1.
-					// we have parameter placeholder at the last index of the common prefix
-					// TODO: this is plain ugly, fix it
-					if (nIdx == 0 || nIdx == 1) || (sIdx == 0 || sIdx == 1) ||
-						(key[pi-1] == t.parameter || n.key[pi-1] == t.parameter) {
-
-						if !strings.HasPrefix(childK2, childK1) {
-							return ErrConflictKey
-						}
-
-					}
-				}
-
-				pnode = &node{
-					key:      childK1,
-					value:    n.value,
-					parent:   n,
-					children: n.children,
-				}
-
-				n.children = []*node{
-					pnode,
-					{
-						key:    childK2,
-						value:  value,
-						parent: n,
-					}}
-
-				n.key = parentK
-				n.value = nil
+				return n
+			}
 
-				// ensure nodes are sorted
-				if n.children[0].key[0] > n.children[1].key[0] {
-					n.children[0], n.children[1] = n.children[1], n.children[0]
-				}
+			// key segment shares a common prefix with the current node
+			// split at the common prefix and add children nodes
+			child := &node{
+				key:    key[pi:],
+				parent: n,
 			}
+			n.children = []*node{pnode, child}
 
-			// update split node children parent
-			for x := 0; x < len(pnode.children); x++ {
-				pnode.children[x].parent = pnode
+			// ensure nodes are sorted
+			if n.children[0].key[0] > n.children[1].key[0] {
+				n.children[0], n.children[1] = n.children[1], n.children[0]
 			}
 
-			t.size++
-			return nil
+			return child
 		}
 
 		key = key[pi:]
@@ -132,38 +122,98 @@ func (t *Tree) set(key string, value interface{}, params bool) (err error) {
 			return n.children[x].key[0] >= key[0]
 		})
 
-		// if insertion index is less than edges size call insertion at
-		// the node at index position
-		if i < len(n.children) {
-			// child at index position shares a prefix with key,
-			// continue iteration
-			if n.children[i].key[0] == key[0] {
-				n = n.children[i]
-				continue
-			}
+		// child at index position shares a prefix with key,
+		// continue iteration
+		if i < len(n.children) && n.children[i].key[0] == key[0] {
+			n = n.children[i]
+			continue
+		}
 
-			// insert node at index position
-			n.children = append(n.children[:i+1], n.children[i:]...)
-			n.children[i] = &node{
-				key:    key,
-				value:  value,
-				parent: n,
+		// insert node at index position
+		child := &node{
+			key:    key,
+			parent: n,
+		}
+
+		n.children = append(n.children, nil)
+		copy(n.children[i+1:], n.children[i:])
+		n.children[i] = child
+
+		return child
+	}
+}
+
+// expandOptional expands a key with optional segments into all the keys it represents
+func (t *Tree) expandOptional(key string) (keys []string, err error) {
+	expanded := make([]byte, 0, len(key))
+	optional := false
+
+	for start := 0; start <= len(key); {
+		end := start
+		for end < len(key) && !t.isDelimiter(key[end]) {
+			end++
+		}
+
+		segment := key[start:end]
+		marker := strings.IndexByte(segment, t.optional)
+
+		switch {
+		// marker is not at the end of the segment or is the whole segment
+		case marker > -1 && (marker != len(segment)-1 || marker == 0):
+			return nil, &PatternError{Key: key, Offset: start + marker, Reason: "misplaced optional segment marker"}
+
+		// required segment following an optional one
+		case marker == -1 && optional:
+			return nil, &PatternError{Key: key, Offset: start, Reason: "required segment after optional segment"}
+
+		case marker > -1:
+			optional = true
+			segment = segment[:marker]
+
+			switch {
+			case len(expanded) > 0:
+				keys = append(keys, string(expanded))
+			case start > 0:
+				keys = append(keys, key[start-1:start])
 			}
+		}
 
-			t.size++
-			return nil
+		// include the delimiter preceding the segment
+		if start > 0 {
+			expanded = append(expanded, key[start-1])
 		}
+		expanded = append(expanded, segment...)
+		start = end + 1
+	}
 
-		// insertion index is bigger than children size, append to it
-		n.children = append(
-			n.children,
-			&node{
-				key:    key,
-				value:  value,
-				parent: n,
-			})
+	return append(keys, string(expanded)), nil
+}
 
-		t.size++
-		return nil
+// setAll sets all keys to the given value, restoring
+// the previous tree state if any of the keys cannot be set
+func (t *Tree) setAll(keys []string, value interface{}) (err error) {
+	folded := make([]string, len(keys))
+	previous := make([]interface{}, len(keys))
+	for x := 0; x < len(keys); x++ {
+		folded[x] = t.foldKey(keys[x])
+		if n, ok := t.get(folded[x]); ok {
+			previous[x] = n.value
+		}
+	}
+
+	for x := 0; x < len(keys); x++ {
+		if err = t.set(folded[x], t.leaf(keys[x], value), true); err != nil {
+			for y := 0; y < x; y++ {
+				if previous[y] != nil {
+					_ = t.set(folded[y], previous[y], true)
+					continue
+				}
+				_ = t.delete(folded[y], false)
+			}
+
+			return err
+		}
 	}
+
+	return nil
 }
@@ -1,6 +1,7 @@
 package radixs
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -88,25 +89,100 @@ func TestSeWithParams(t *testing.T) {
 	key = "/api/v1/projects//:project/instances/:instance/databases/:database"
 	value = "DatabaseHandler"
 	err = tr.SetWithParams(key, value)
-	assert(err == ErrInvalidKey, "set invalid key:", key, "error:", err)
+	assert(errors.Is(err, ErrInvalidKey), "set invalid key:", key, "error:", err)
 
 	key = "/api/v1:/projects/:project/instances/:instance/databases/:database"
 	value = "DatabaseHandler"
 	err = tr.SetWithParams(key, value)
-	assert(err == ErrInvalidKey, "set invalid key:", key, "error:", err)
+	assert(errors.Is(err, ErrInvalidKey), "set invalid key:", key, "error:", err)
 
 	key = "/api/v1/projects/::project/instances/:instance/databases/:database"
 	value = "DatabaseHandler"
 	err = tr.SetWithParams(key, value)
-	assert(err == ErrInvalidKey, "set invalid key:", key, "error:", err)
+	assert(errors.Is(err, ErrInvalidKey), "set invalid key:", key, "error:", err)
 
 	key = "/api/v1/projects/project/instances/:instance/databases/:database"
 	value = "DatabaseHandler"
 	err = tr.SetWithParams(key, value)
-	assert(err == ErrConflictKey, "set conflicting key:", key, "error:", err)
+	assert(errors.Is(err, ErrConflictKey), "set conflicting key:", key, "error:", err)
 
 	key = "/api/v1/projects/:state/instances/:instance/databases/:database"
 	value = "DatabaseHandler"
 	err = tr.SetWithParams(key, value)
-	assert(err == ErrConflictKey, "set conflicting key:", key, "error:", err)
+	assert(errors.Is(err, ErrConflictKey), "set conflicting key:", key, "error:", err)
+}
+
+func TestSetWithParamsMidSegment(t *testing.T) {
+	assert := newAssert(t)
+
+	tests := []struct {
+		name string
+		keys []string
+		err  error
+	}{
+		{"literal suffix", []string{"/img/:name.:ext"}, nil},
+		{"literal prefix", []string{"/v:major.:minor/docs"}, nil},
+		{"adjacent params", []string{"/img/:a:b"}, ErrInvalidKey},
+		{"adjacent params after literal", []string{"/img/x:a:b.png"}, ErrInvalidKey},
+		{"empty param name", []string{"/img/:.png"}, ErrInvalidKey},
+		{"trailing param placeholder", []string{"/img/:"}, ErrInvalidKey},
+		{"different literal after param", []string{"/img/:name.:ext", "/img/:name-:size"}, ErrConflictKey},
+		{"param and literal after literal", []string{"/img/:name.:ext", "/img/:name.png"}, ErrConflictKey},
+		{"different suffix after param", []string{"/img/:name.png", "/img/:name-thumb"}, ErrConflictKey},
+		{"shared literal after param", []string{"/img/:name.png", "/img/:name.jpg"}, nil},
+	}
+
+	for _, tt := range tests {
+		tr := New(WithParams('/', ':'))
+
+		var err error
+		for x := 0; x < len(tt.keys) && err == nil; x++ {
+			err = tr.SetWithParams(tt.keys[x], tt.name)
+		}
+
+		assert(errors.Is(err, tt.err), tt.name, "keys:", tt.keys, "expected:", tt.err, "got:", err)
+	}
+}
+
+func TestSetWithParamsOptionalSegments(t *testing.T) {
+	assert := newAssert(t)
+
+	tests := []struct {
+		key     string
+		err     error
+		matches []string
+	}{
+		{"/docs/:page?", nil, []string{"/docs", "/docs/intro"}},
+		{"/:lang?", nil, []string{"/", "/en"}},
+		{"/archive/:year?/:month?", nil, []string{"/archive", "/archive/2022", "/archive/2022/02"}},
+		{"/users/all?", nil, []string{"/users", "/users/all"}},
+		{"/docs/:page?/edit", ErrInvalidKey, nil},
+		{"/docs/?", ErrInvalidKey, nil},
+		{"/docs/:pa?ge", ErrInvalidKey, nil},
+	}
+
+	for _, tt := range tests {
+		tr := New(WithParams('/', ':'), WithOptionalSegments('?'))
+		err := tr.SetWithParams(tt.key, tt.key)
+		assert(errors.Is(err, tt.err), "key:", tt.key, "expected:", tt.err, "got:", err)
+		assert(tr.Size() == uint64(len(tt.matches)), "key:", tt.key, "expected size:", len(tt.matches), "got:", tr.Size())
+
+		for _, match := range tt.matches {
+			value, err := tr.GetWithParams(match, map[string]string{})
+			assert(err == nil && value == tt.key, "key:", tt.key, "not matched by:", match, "err:", err)
+		}
+	}
+
+	tr := New(WithParams('/', ':'), WithOptionalSegments('?'))
+	err := tr.SetWithParams("/docs/:page/history", "history")
+	assert(err == nil, "error setting key:", "/docs/:page/history", "error:", err)
+	err = tr.SetWithParams("/docs", "docs")
+	assert(err == nil, "error setting key:", "/docs", "error:", err)
+
+	err = tr.SetWithParams("/docs/:name?", "conflict")
+	assert(errors.Is(err, ErrConflictKey), "set conflicting optional key, error:", err)
+	assert(tr.Size() == 2, "tree not restored after conflict, size:", tr.Size())
+
+	value, err := tr.Get("/docs")
+	assert(err == nil && value == "docs", "value not restored after conflict:", value, "err:", err)
 }
@@ -0,0 +1,140 @@
+package radixs
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+)
+
+// Suggestion is a key completion as returned by Suggest
+type Suggestion struct {
+	Key   string
+	Value interface{}
+	Score float64
+}
+
+// Suggest returns up to limit keys starting with the given prefix with the highest scores,
+// sorted by descending score and ascending key. Only the best suggestions are kept in a
+// bounded heap while walking the keys under the prefix.
+func (t *Tree) Suggest(prefix string, limit int, score func(key string, value interface{}) float64) (suggestions []Suggestion) {
+	return t.SuggestWithBound(prefix, limit, score, nil)
+}
+
+// SuggestWithBound is like Suggest, but uses bound, if not nil, to obtain an upper bound for
+// the score of all keys starting with a given key prefix. Branches whose bound cannot beat the
+// current suggestions are skipped and the walk stops once no remaining branch can beat them.
+func (t *Tree) SuggestWithBound(prefix string, limit int, score func(key string, value interface{}) float64,
+	bound func(prefix string) float64) (suggestions []Suggestion) {
+
+	n, ok := t.prefixNode(t.foldKey(prefix))
+	if !ok || limit <= 0 {
+		return nil
+	}
+
+	path := ""
+	if n.parent != nil {
+		path = n.parent.fullKey()
+	}
+
+	best := &suggestionHeap{}
+	add := func(key string, value interface{}) {
+		s := Suggestion{Key: key, Value: value, Score: score(key, value)}
+		switch {
+		case best.Len() < limit:
+			heap.Push(best, s)
+		case s.better((*best)[0]):
+			(*best)[0] = s
+			heap.Fix(best, 0)
+		}
+	}
+
+	// no bound, walk all keys under prefix
+	if bound == nil {
+		n.iter(path, func(key string, value interface{}) bool {
+			add(key, value)
+			return true
+		})
+
+		return best.sorted()
+	}
+
+	// best first walk of the branches ordered by their bound
+	branches := &branchHeap{{key: path + n.key, n: n, bound: math.Inf(1)}}
+	for branches.Len() > 0 {
+		b := heap.Pop(branches).(branch)
+
+		// no remaining branch can beat the current suggestions
+		if best.Len() == limit && b.bound < (*best)[0].Score {
+			break
+		}
+
+		if b.n.value != nil {
+			add(b.n.entry(b.key))
+		}
+
+		for x := 0; x < len(b.n.children); x++ {
+			key := b.key + b.n.children[x].key
+			upper := bound(key)
+			if best.Len() == limit && upper < (*best)[0].Score {
+				continue
+			}
+
+			heap.Push(branches, branch{key: key, n: b.n.children[x], bound: upper})
+		}
+	}
+
+	return best.sorted()
+}
+
+// better checks if s ranks before o
+func (s Suggestion) better(o Suggestion) (ok bool) {
+	if s.Score != o.Score {
+		return s.Score > o.Score
+	}
+	return s.Key < o.Key
+}
+
+// suggestionHeap is a min heap of the best suggestions, with the worst at the root
+type suggestionHeap []Suggestion
+
+func (h suggestionHeap) Len() int           { return len(h) }
+func (h suggestionHeap) Less(i, j int) bool { return h[j].better(h[i]) }
+func (h suggestionHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *suggestionHeap) Push(x interface{}) { *h = append(*h, x.(Suggestion)) }
+
+func (h *suggestionHeap) Pop() (x interface{}) {
+	old := *h
+	x = old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// sorted returns the suggestions from best to worst
+func (h suggestionHeap) sorted() (suggestions []Suggestion) {
+	sort.Slice(h, func(i, j int) bool { return h[i].better(h[j]) })
+	return h
+}
+
+// branch is a subtree under key with an upper bound for the score of its keys
+type branch struct {
+	key   string
+	n     *node
+	bound float64
+}
+
+// branchHeap is a max heap of branches by bound
+type branchHeap []branch
+
+func (h branchHeap) Len() int           { return len(h) }
+func (h branchHeap) Less(i, j int) bool { return h[i].bound > h[j].bound }
+func (h branchHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *branchHeap) Push(x interface{}) { *h = append(*h, x.(branch)) }
+
+func (h *branchHeap) Pop() (x interface{}) {
+	old := *h
+	x = old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,98 @@
+package radixs
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestSuggest(t *testing.T) {
+	assert := newAssert(t)
+	tr := New()
+
+	scores := map[string]float64{
+		"radix":       5,
+		"radix tree":  9,
+		"radix sort":  3,
+		"rad":         1,
+		"random":      7,
+		"tree":        12,
+		"trie":        2,
+		"radixs tree": 4,
+		"ramen":       7,
+	}
+
+	for k, v := range scores {
+		_ = tr.Set(k, v)
+	}
+
+	var calls int
+	score := func(key string, value interface{}) float64 {
+		calls++
+		return value.(float64)
+	}
+
+	expected := []Suggestion{{"radix tree", 9.0, 9}, {"ramen", 7.0, 7}, {"random", 7.0, 7}}
+	suggestions := tr.Suggest("ra", 3, score)
+	assert(len(suggestions) == len(expected), "expected:", expected, "got:", suggestions)
+	for x := 0; x < len(suggestions) && x < len(expected); x++ {
+		assert(suggestions[x] == expected[x], "expected:", expected, "got:", suggestions)
+	}
+	assert(calls == 7, "expected all 7 keys under prefix to be scored, got:", calls)
+
+	// upper bound for each prefix is the maximum score of the keys under it
+	bound := func(prefix string) float64 {
+		max := 0.0
+		for k, v := range scores {
+			if strings.HasPrefix(k, prefix) && v > max {
+				max = v
+			}
+		}
+		return max
+	}
+
+	calls = 0
+	suggestions = tr.SuggestWithBound("ra", 3, score, bound)
+	assert(len(suggestions) == len(expected), "expected:", expected, "got:", suggestions)
+	for x := 0; x < len(suggestions) && x < len(expected); x++ {
+		assert(suggestions[x] == expected[x], "expected:", expected, "got:", suggestions)
+	}
+	assert(calls < 7, "expected pruned branches, got calls:", calls)
+
+	suggestions = tr.Suggest("x", 3, score)
+	assert(len(suggestions) == 0, "unexpected suggestions for missing prefix:", suggestions)
+
+	suggestions = tr.Suggest("", 0, score)
+	assert(len(suggestions) == 0, "unexpected suggestions for zero limit:", suggestions)
+}
+
+func TestSuggestBrute(t *testing.T) {
+	assert := newAssert(t)
+	tr, _ := FromMap(pairs)
+
+	score := func(key string, value interface{}) float64 {
+		return float64(len(key) % 7)
+	}
+
+	bound := func(prefix string) float64 { return 6 }
+
+	for _, prefix := range []string{"", "r", "ro", "rom", "s", "sma"} {
+		var expected []Suggestion
+		for k, v := range pairs {
+			if strings.HasPrefix(k, prefix) {
+				expected = append(expected, Suggestion{k, v, score(k, v)})
+			}
+		}
+		sort.Slice(expected, func(i, j int) bool { return expected[i].better(expected[j]) })
+		if len(expected) > 5 {
+			expected = expected[:5]
+		}
+
+		for _, suggestions := range [][]Suggestion{tr.Suggest(prefix, 5, score), tr.SuggestWithBound(prefix, 5, score, bound)} {
+			assert(len(suggestions) == len(expected), "prefix:", prefix, "expected:", expected, "got:", suggestions)
+			for x := 0; x < len(suggestions) && x < len(expected); x++ {
+				assert(suggestions[x] == expected[x], "prefix:", prefix, "expected:", expected, "got:", suggestions)
+			}
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package radixs
+
+import (
+	"strings"
+)
+
+// syntax holds the key pattern syntax used by parameterized keys
+type syntax struct {
+	delimiters string // set of delimiter bytes, the first one being the primary delimiter
+	open       string // parameter opening token
+	close      string // parameter closing token, if any
+	wildcard   byte   // wildcard placeholder, if any
+	constraint byte   // parameter type constraint separator, if any
+	optional   byte   // optional segment marker, if any
+	class      [256]byteClass
+}
+
+// byteClass holds the syntax classes of a byte, precomputed by compile
+type byteClass uint8
+
+const (
+	classDelimiter byteClass = 1 << iota
+	classParam
+	className
+	classClose
+)
+
+// compile precomputes the byte classes for the configured syntax
+func (s *syntax) compile() {
+	for c := 0; c < len(s.class); c++ {
+		s.class[c] = 0
+	}
+
+	for x := 0; x < len(s.delimiters); x++ {
+		s.class[s.delimiters[x]] |= classDelimiter
+	}
+
+	if s.open != "" {
+		s.class[s.open[0]] |= classParam
+	}
+
+	if s.wildcard != 0 {
+		s.class[s.wildcard] |= classParam
+	}
+
+	if s.close != "" {
+		s.class[s.close[0]] |= classClose
+	}
+
+	for c := 0; c < len(s.class); c++ {
+		b := byte(c)
+		if s.class[c] != 0 || (s.constraint != 0 && b == s.constraint) {
+			continue
+		}
+
+		if b == '_' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') {
+			s.class[c] |= className
+		}
+	}
+}
+
+// isDelimiter checks if c is one of the delimiters
+func (s *syntax) isDelimiter(c byte) (ok bool) {
+	return s.class[c]&classDelimiter != 0
+}
+
+// isParamByte checks if c starts a parameter or wildcard placeholder
+func (s *syntax) isParamByte(c byte) (ok bool) {
+	return s.class[c]&classParam != 0
+}
+
+// isNameByte checks if c can be part of a parameter name
+func (s *syntax) isNameByte(c byte) (ok bool) {
+	return s.class[c]&className != 0
+}
+
+// paramName returns the length of the parameter name at the start of key.
+// Parameter names are made of ASCII letters, digits and underscores.
+func (s *syntax) paramName(key string) (l int) {
+	for l < len(key) && s.isNameByte(key[l]) {
+		l++
+	}
+	return l
+}
+
+// paramToken parses the parameter or wildcard at the start of key, returning its
+// name, type constraint and the remaining of the key after it. If the parameter
+// is malformed it returns the reason and the remaining of the key where it was found.
+func (s *syntax) paramToken(key string) (name, kind, rest string, wildcard bool, reason string) {
+	var l int
+	switch {
+	case s.wildcard != 0 && key[0] == s.wildcard:
+		wildcard = true
+		l = 1
+	case s.open != "" && strings.HasPrefix(key, s.open):
+		l = len(s.open)
+	default:
+		return "", "", key, false, "incomplete parameter token"
+	}
+
+	n := s.paramName(key[l:])
+	if n == 0 {
+		return "", "", key, wildcard, "empty parameter name"
+	}
+	name = key[l : l+n]
+	rest = key[l+n:]
+
+	if s.constraint != 0 && rest != "" && rest[0] == s.constraint {
+		c := s.paramName(rest[1:])
+		if c == 0 {
+			return name, "", rest, wildcard, "empty parameter constraint"
+		}
+		kind = rest[1 : c+1]
+		rest = rest[c+1:]
+	}
+
+	if !wildcard && s.close != "" {
+		if !strings.HasPrefix(rest, s.close) {
+			return name, kind, rest, wildcard, "unterminated parameter"
+		}
+		rest = rest[len(s.close):]
+	}
+
+	return name, kind, rest, wildcard, ""
+}
+
+// paramValue returns the parameter value at the start of key, which ends
+// at the next delimiter or at the literal byte following the parameter in the pattern
+func (s *syntax) paramValue(key, pattern string) (value string) {
+	for x := 0; x < len(key); x++ {
+		if s.isDelimiter(key[x]) || (pattern != "" && key[x] == pattern[0]) {
+			return key[:x]
+		}
+	}
+
+	return key
+}
+
+// inParam checks if the key ends inside a parameter token or name or right after
+// a parameter, where the following byte determines where the parameter value ends
+func (s *syntax) inParam(key string) (ok bool) {
+	// complete or partial closing token
+	for l := len(s.close); l > 0; l-- {
+		if strings.HasSuffix(key, s.close[:l]) {
+			key = key[:len(key)-l]
+			break
+		}
+	}
+
+	l := len(key)
+	for l > 0 && s.isNameByte(key[l-1]) {
+		l--
+	}
+
+	// complete or partial type constraint
+	if s.constraint != 0 && l > 0 && key[l-1] == s.constraint {
+		l--
+		for l > 0 && s.isNameByte(key[l-1]) {
+			l--
+		}
+	}
+	key = key[:l]
+
+	if s.wildcard != 0 && l > 0 && key[l-1] == s.wildcard {
+		return true
+	}
+
+	// complete or partial opening token
+	for l := len(s.open); l > 0; l-- {
+		if strings.HasSuffix(key, s.open[:l]) {
+			return true
+		}
+	}
+
+	return false
+}
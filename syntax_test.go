@@ -0,0 +1,148 @@
+package radixs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSyntaxDelimiters(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithDelimiters("/."), WithParamSyntax(":", ""))
+
+	keys := []string{"metrics.:host/cpu", "metrics.:host/mem", "metrics.:host/disk.:disk/io"}
+	for _, key := range keys {
+		err := tr.SetWithParams(key, key)
+		assert(err == nil, "error setting key:", key, "error:", err)
+	}
+
+	tests := []struct {
+		key    string
+		value  string
+		params map[string]string
+	}{
+		{"metrics.db1/cpu", "metrics.:host/cpu", map[string]string{"host": "db1"}},
+		{"metrics.db1/mem", "metrics.:host/mem", map[string]string{"host": "db1"}},
+		{"metrics.db1/disk.sda/io", "metrics.:host/disk.:disk/io", map[string]string{"host": "db1", "disk": "sda"}},
+	}
+
+	for _, tt := range tests {
+		params := map[string]string{}
+		value, err := tr.GetWithParams(tt.key, params)
+		assert(err == nil && value == tt.value, "key:", tt.key, "expected:", tt.value, "got:", value, "err:", err)
+
+		for name, v := range tt.params {
+			assert(params[name] == v, "key:", tt.key, "param:", name, "expected:", v, "got:", params[name])
+		}
+	}
+
+	_, err := tr.GetWithParams("metrics.db1/disk", map[string]string{})
+	assert(err == ErrKeyNotFound, "unexpected match for metrics.db1/disk, err:", err)
+
+	err = tr.SetWithParams("metrics.:host.:disk", "conflict")
+	assert(errors.Is(err, ErrConflictKey), "set key with different delimiter after param, err:", err)
+
+	err = tr.SetWithParams("metrics./cpu", "empty")
+	assert(errors.Is(err, ErrInvalidKey), "set key with empty segment, err:", err)
+}
+
+func TestSyntaxParamTokens(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithDelimiters("/"), WithParamSyntax("{", "}"))
+
+	keys := []string{"/users/{user}/repos/{repo}", "/users/{user}", "/img/{name}.{ext}"}
+	for _, key := range keys {
+		err := tr.SetWithParams(key, key)
+		assert(err == nil, "error setting key:", key, "error:", err)
+	}
+
+	tests := []struct {
+		key    string
+		value  string
+		params map[string]string
+	}{
+		{"/users/bob", "/users/{user}", map[string]string{"user": "bob"}},
+		{"/users/bob/repos/radixs", "/users/{user}/repos/{repo}", map[string]string{"user": "bob", "repo": "radixs"}},
+		{"/img/logo.png", "/img/{name}.{ext}", map[string]string{"name": "logo", "ext": "png"}},
+	}
+
+	for _, tt := range tests {
+		params := map[string]string{}
+		value, err := tr.GetWithParams(tt.key, params)
+		assert(err == nil && value == tt.value, "key:", tt.key, "expected:", tt.value, "got:", value, "err:", err)
+
+		for name, v := range tt.params {
+			assert(params[name] == v, "key:", tt.key, "param:", name, "expected:", v, "got:", params[name])
+		}
+	}
+
+	routes := tr.Routes()
+	for _, r := range routes {
+		pattern, err := tr.Pattern(r.Segments)
+		assert(err == nil && pattern == r.Pattern, "pattern:", r.Pattern, "rebuilt as:", pattern, "err:", err)
+	}
+}
+
+func TestSyntaxParamTokensInvalid(t *testing.T) {
+	assert := newAssert(t)
+
+	tests := []struct {
+		key    string
+		offset int
+		reason string
+	}{
+		{"/users/{user", 12, "unterminated parameter"},
+		{"/users/{}", 7, "empty parameter name"},
+		{"/img/{a}{b}", 8, "adjacent parameters"},
+		{"/users/user}", 11, "unexpected parameter closing token"},
+		{"/users/{{user}}", 7, "empty parameter name"},
+		{"/users//{user}", 7, "empty segment"},
+	}
+
+	for _, tt := range tests {
+		tr := New(WithDelimiters("/"), WithParamSyntax("{", "}"))
+		err := tr.SetWithParams(tt.key, tt.key)
+
+		var perr *PatternError
+		assert(errors.As(err, &perr), "key:", tt.key, "expected *PatternError, got:", err)
+		assert(perr.Offset == tt.offset && perr.Reason == tt.reason,
+			"key:", tt.key, "expected:", tt.reason, "at", tt.offset, "got:", perr.Reason, "at", perr.Offset)
+	}
+
+	tr := New(WithDelimiters("/"), WithParamSyntax("{{", "}}"))
+	err := tr.SetWithParams("/users/{user}}", "incomplete")
+	assert(errors.Is(err, ErrInvalidKey), "set key with incomplete parameter token, err:", err)
+
+	err = tr.SetWithParams("/users/{{user}}", "users")
+	assert(err == nil, "error setting key with multi-byte tokens, err:", err)
+
+	params := map[string]string{}
+	value, err := tr.GetWithParams("/users/bob", params)
+	assert(err == nil && value == "users" && params["user"] == "bob", "value:", value, "params:", params, "err:", err)
+}
+
+func TestSyntaxConflicts(t *testing.T) {
+	assert := newAssert(t)
+
+	tests := []struct {
+		name string
+		keys []string
+		err  error
+	}{
+		{"shared param", []string{"/users/{user}", "/users/{user}/repos"}, nil},
+		{"different param name", []string{"/users/{user}", "/users/{name}"}, ErrConflictKey},
+		{"param and static", []string{"/users/{user}", "/users/bob"}, ErrConflictKey},
+		{"different literal after param", []string{"/img/{name}.{ext}", "/img/{name}-{size}"}, ErrConflictKey},
+		{"shared literal after param", []string{"/img/{name}.png", "/img/{name}.jpg"}, nil},
+	}
+
+	for _, tt := range tests {
+		tr := New(WithDelimiters("/"), WithParamSyntax("{", "}"))
+
+		var err error
+		for x := 0; x < len(tt.keys) && err == nil; x++ {
+			err = tr.SetWithParams(tt.keys[x], tt.name)
+		}
+
+		assert(errors.Is(err, tt.err), tt.name, "keys:", tt.keys, "expected:", tt.err, "got:", err)
+	}
+}
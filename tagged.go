@@ -0,0 +1,155 @@
+package radixs
+
+import (
+	"sort"
+)
+
+// Tagged holds the values set with SetTagged for a single key, sorted by tag.
+// It is the value returned for those keys by Get, Iter and the other read methods.
+// It is immutable, as tagged values are updated by replacing it.
+type Tagged struct {
+	tags   []string
+	values []interface{}
+}
+
+// Get returns the value for the given tag
+func (v *Tagged) Get(tag string) (value interface{}, ok bool) {
+	i := sort.SearchStrings(v.tags, tag)
+	if i < len(v.tags) && v.tags[i] == tag {
+		return v.values[i], true
+	}
+
+	return nil, false
+}
+
+// Tags returns a copy of the sorted tags
+func (v *Tagged) Tags() (tags []string) {
+	return append([]string{}, v.tags...)
+}
+
+// Len returns the number of tags
+func (v *Tagged) Len() (l int) {
+	return len(v.tags)
+}
+
+// with returns a copy of v with the value set for the given tag
+func (v *Tagged) with(tag string, value interface{}) (c *Tagged) {
+	i := sort.SearchStrings(v.tags, tag)
+	if i < len(v.tags) && v.tags[i] == tag {
+		c = &Tagged{tags: v.tags, values: append([]interface{}{}, v.values...)}
+		c.values[i] = value
+		return c
+	}
+
+	c = &Tagged{
+		tags:   make([]string, 0, len(v.tags)+1),
+		values: make([]interface{}, 0, len(v.values)+1),
+	}
+	c.tags = append(append(append(c.tags, v.tags[:i]...), tag), v.tags[i:]...)
+	c.values = append(append(append(c.values, v.values[:i]...), value), v.values[i:]...)
+	return c
+}
+
+// without returns a copy of v without the given tag
+func (v *Tagged) without(tag string) (c *Tagged, ok bool) {
+	i := sort.SearchStrings(v.tags, tag)
+	if i >= len(v.tags) || v.tags[i] != tag {
+		return v, false
+	}
+
+	c = &Tagged{
+		tags:   make([]string, 0, len(v.tags)-1),
+		values: make([]interface{}, 0, len(v.values)-1),
+	}
+	c.tags = append(append(c.tags, v.tags[:i]...), v.tags[i+1:]...)
+	c.values = append(append(c.values, v.values[:i]...), v.values[i+1:]...)
+	return c, true
+}
+
+// SetTagged sets or updates the value for the given tag in the key, allowing
+// several values per key like the handlers for each HTTP method of a path.
+// Setting a tagged value for a key set with Set returns ErrConflictKey.
+func (t *Tree) SetTagged(key, tag string, value interface{}) (err error) {
+	if value == nil {
+		return ErrNilValue
+	}
+
+	v := &Tagged{}
+	_, current, err := t.getValue(key)
+	switch {
+	case err == nil:
+		var ok bool
+		if v, ok = current.(*Tagged); !ok {
+			return ErrConflictKey
+		}
+	case err != ErrKeyNotFound:
+		return err
+	}
+
+	return t.set(t.foldKey(key), t.leaf(key, v.with(tag, value)), false)
+}
+
+// GetTagged retrieves the value for the given tag in the key.
+// It returns ErrTagNotFound if the key exists without the tag.
+func (t *Tree) GetTagged(key, tag string) (value interface{}, err error) {
+	v, err := t.getTagged(key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := v.Get(tag)
+	if !ok {
+		return nil, ErrTagNotFound
+	}
+
+	return value, nil
+}
+
+// Tags returns the sorted tags set for the given key
+func (t *Tree) Tags(key string) (tags []string, err error) {
+	v, err := t.getTagged(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Tags(), nil
+}
+
+// DeleteTagged removes the given tag from the key. The key
+// is removed from the tree once its last tag is removed.
+func (t *Tree) DeleteTagged(key, tag string) (err error) {
+	canonical, v, err := t.getTaggedCanonical(key)
+	if err != nil {
+		return err
+	}
+
+	v, ok := v.without(tag)
+	if !ok {
+		return ErrTagNotFound
+	}
+
+	if len(v.tags) == 0 {
+		return t.delete(t.foldKey(key), false)
+	}
+
+	return t.set(t.foldKey(key), t.leaf(canonical, v), false)
+}
+
+func (t *Tree) getTagged(key string) (v *Tagged, err error) {
+	_, v, err = t.getTaggedCanonical(key)
+	return v, err
+}
+
+func (t *Tree) getTaggedCanonical(key string) (canonical string, v *Tagged, err error) {
+	canonical, value, err := t.getValue(key)
+	if err != nil {
+		return "", nil, err
+	}
+
+	v, ok := value.(*Tagged)
+	if !ok {
+		return "", nil, ErrTagNotFound
+	}
+
+	return canonical, v, nil
+}
@@ -0,0 +1,96 @@
+package radixs
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTagged(t *testing.T) {
+	assert := newAssert(t)
+	tr := New()
+
+	for _, tag := range []string{"POST", "GET", "DELETE"} {
+		err := tr.SetTagged("/users", tag, tag+" /users")
+		assert(err == nil, "error setting tag:", tag, "error:", err)
+	}
+	_ = tr.SetTagged("/users/bob", "GET", "GET /users/bob")
+	_ = tr.SetTagged("/users", "GET", "GET /users updated")
+
+	assert(tr.Size() == 2, "expected size 2, got:", tr.Size())
+
+	tags, err := tr.Tags("/users")
+	assert(err == nil && len(tags) == 3 && tags[0] == "DELETE" && tags[1] == "GET" && tags[2] == "POST", "wrong tags:", tags, "err:", err)
+
+	value, err := tr.GetTagged("/users", "GET")
+	assert(err == nil && value == "GET /users updated", "wrong value:", value, "err:", err)
+
+	// read methods return the tagged values of the key
+	value, err = tr.Get("/users")
+	v, ok := value.(*Tagged)
+	assert(err == nil && ok && v.Len() == 3, "wrong tagged values:", value, "err:", err)
+	if ok {
+		value, ok = v.Get("POST")
+		assert(ok && value == "POST /users" && len(v.Tags()) == 3, "wrong tagged value:", value)
+	}
+
+	value, err = tr.GetTagged("/users", "PUT")
+	assert(err == ErrTagNotFound, "unexpected value for missing tag:", value, "err:", err)
+
+	_, err = tr.GetTagged("/groups", "GET")
+	assert(err == ErrKeyNotFound, "unexpected error for missing key:", err)
+
+	err = tr.SetTagged("/users", "GET", nil)
+	assert(err == ErrNilValue, "nil tagged value was set, err:", err)
+
+	_ = tr.Set("/groups", "groups")
+	err = tr.SetTagged("/groups", "GET", "GET /groups")
+	assert(err == ErrConflictKey, "tagged value set for untagged key, err:", err)
+
+	_, err = tr.GetTagged("/groups", "GET")
+	assert(err == ErrTagNotFound, "tagged value found for untagged key, err:", err)
+
+	err = tr.DeleteTagged("/users", "PUT")
+	assert(err == ErrTagNotFound, "deleted missing tag, err:", err)
+
+	for _, tag := range []string{"POST", "GET"} {
+		err = tr.DeleteTagged("/users", tag)
+		assert(err == nil, "error deleting tag:", tag, "error:", err)
+	}
+
+	tags, _ = tr.Tags("/users")
+	assert(len(tags) == 1 && tags[0] == "DELETE", "wrong tags after delete:", tags)
+
+	err = tr.DeleteTagged("/users", "DELETE")
+	assert(err == nil, "error deleting last tag, err:", err)
+
+	_, err = tr.Tags("/users")
+	assert(err == ErrKeyNotFound, "key not removed after deleting last tag, err:", err)
+	assert(tr.Size() == 2, "expected size 2, got:", tr.Size())
+
+	// the remaining key must be merged back into a single node
+	n, _ := tr.get("/users/bob")
+	assert(n.key == "users/bob", "tree not merged:", tr.String())
+
+	value, err = tr.GetTagged("/users/bob", "GET")
+	assert(err == nil && value == "GET /users/bob", "wrong value:", value, "err:", err)
+}
+
+func TestTaggedCaseFolding(t *testing.T) {
+	assert := newAssert(t)
+	tr := New(WithCaseFolding())
+
+	_ = tr.SetTagged("/Users", "GET", "get")
+	_ = tr.SetTagged("/Users", "POST", "post")
+
+	value, err := tr.GetTagged("/USERS", "POST")
+	assert(err == nil && value == "post", "wrong value:", value, "err:", err)
+
+	err = tr.SetTagged("/users", "PUT", "put")
+	assert(errors.Is(err, ErrConflictKey), "tagged value set for key folding to existing key, err:", err)
+
+	err = tr.DeleteTagged("/USERS", "GET")
+	assert(err == nil, "error deleting tag, err:", err)
+
+	canonical, _, err := tr.GetCanonical("/users")
+	assert(err == nil && canonical == "/Users", "registered key not preserved:", canonical, "err:", err)
+}
@@ -2,14 +2,19 @@ package radixs
 
 import (
 	"fmt"
+	"strings"
 )
 
 var (
-	ErrKeyNotFound = fmt.Errorf("radixs: key not found")
-	ErrEmptyKey    = fmt.Errorf("radixs: key cannot be empty")
-	ErrNilValue    = fmt.Errorf("radixs: value cannot be nil")
-	ErrConflictKey = fmt.Errorf("radixs: conflicting key")
-	ErrInvalidKey  = fmt.Errorf("radixs: invalid key")
+	ErrKeyNotFound      = fmt.Errorf("radixs: key not found")
+	ErrEmptyKey         = fmt.Errorf("radixs: key cannot be empty")
+	ErrNilValue         = fmt.Errorf("radixs: value cannot be nil")
+	ErrConflictKey      = fmt.Errorf("radixs: conflicting key")
+	ErrInvalidKey       = fmt.Errorf("radixs: invalid key")
+	ErrIncompatibleTree = fmt.Errorf("radixs: incompatible tree configuration")
+	ErrInvalidParam     = fmt.Errorf("radixs: invalid parameter value")
+	ErrTagNotFound      = fmt.Errorf("radixs: tag not found")
+	ErrValueNotFound    = fmt.Errorf("radixs: value not found")
 )
 
 // Tree is a compact radix (compact prefix) tree which is guaranteed
@@ -17,10 +22,15 @@ var (
 // using binary searches making the tree operations very efficient
 // for large trees.
 type Tree struct {
-	size      uint64
-	root      *node
-	delimiter byte
-	parameter byte
+	syntax
+	size              uint64
+	postings          uint64
+	root              *node
+	trailingDelimiter bool
+	folding           caseFolding
+	counting          bool
+	maxes             map[*node]int64 // maximum count in each subtree with counts, in the counting mode
+	globCross         bool
 }
 
 // New creates a new radix tree
@@ -32,6 +42,7 @@ func New(opts ...OptFunc) (t *Tree) {
 	for x := 0; x < len(opts); x++ {
 		opts[x](t)
 	}
+	t.compile()
 
 	return t
 }
@@ -43,11 +54,74 @@ type OptFunc func(t *Tree)
 // when working with path parameter in keys
 func WithParams(delimiter, parameter byte) (opt OptFunc) {
 	return func(t *Tree) {
-		t.delimiter = delimiter
-		t.parameter = parameter
+		t.delimiters = string(delimiter)
+		t.open = string(parameter)
+		t.close = ""
 	}
 }
 
+// WithDelimiters sets the tree key delimiters to the given set of bytes, e.g. "/."
+// for keys like metrics.:host/cpu. The first delimiter is used when a delimiter
+// must be added to a key, as with WithTrailingDelimiterTolerance.
+func WithDelimiters(delimiters string) (opt OptFunc) {
+	return func(t *Tree) {
+		t.delimiters = delimiters
+	}
+}
+
+// WithParamSyntax sets the opening and closing tokens for parameters, e.g. "{" and "}"
+// for keys like /users/{user}. Tokens may have multiple bytes and the closing token
+// may be empty, in which case the parameter name ends at the first byte that is not
+// an ASCII letter, digit or underscore.
+func WithParamSyntax(open, close string) (opt OptFunc) {
+	return func(t *Tree) {
+		t.open = open
+		t.close = close
+	}
+}
+
+// WithWildcard sets the wildcard placeholder for keys set with SetWithParams.
+// A wildcard must be the last segment of a key and its value, as returned
+// by GetWithParams, is the remaining of the key including delimiters,
+// e.g. with '*' as placeholder /files/*path matches /files/docs/intro.md.
+func WithWildcard(wildcard byte) (opt OptFunc) {
+	return func(t *Tree) {
+		t.wildcard = wildcard
+	}
+}
+
+// WithParamConstraints enables parameter type constraints in keys set with SetWithParams.
+// A parameter name followed by the given separator and a type, e.g. with '|' as
+// separator /users/:id|int, only matches values of that type, and GetWithParams
+// returns a *ParamError for values that are not.
+// Supported types are int, int64, uint, bool and uuid.
+func WithParamConstraints(separator byte) (opt OptFunc) {
+	return func(t *Tree) {
+		t.constraint = separator
+	}
+}
+
+// WithTrailingDelimiterTolerance makes Get and GetWithParams treat a trailing
+// delimiter as optional, so /users/ matches /users and vice versa.
+// It requires the delimiters to be configured using WithParams or WithDelimiters.
+func WithTrailingDelimiterTolerance() (opt OptFunc) {
+	return func(t *Tree) {
+		t.trailingDelimiter = true
+	}
+}
+
+// WithOptionalSegments enables optional segments in keys set with SetWithParams.
+// A segment ending with the given marker is optional and is internally expanded
+// into one key with and one without the segment, e.g. with '?' as marker
+// /docs/:page? is expanded into /docs and /docs/:page.
+// Optional segments can only be followed by other optional segments.
+func WithOptionalSegments(marker byte) (opt OptFunc) {
+	return func(t *Tree) {
+		t.optional = marker
+	}
+}
+
+// FromMap creates a new radix tree from the given map
 func FromMap(m map[string]interface{}, opts ...OptFunc) (t *Tree, err error) {
 	t = New(opts...)
 
@@ -62,24 +136,64 @@ func FromMap(m map[string]interface{}, opts ...OptFunc) (t *Tree, err error) {
 
 // Set or update the value for the given key
 func (t *Tree) Set(key string, value interface{}) (err error) {
-	return t.set(key, value, false)
+	return t.set(t.foldKey(key), t.leaf(key, value), false)
 }
 
 // SetWithParams is like Set, but provides additional validation
-// to prevent invalid keys and conflicts when working with key parameters
+// to prevent invalid keys and conflicts when working with key parameters.
+// Parameter names are made of ASCII letters, digits and underscores and
+// must be followed by a delimiter, a literal byte or the end of the key.
+// Keys conflicting with existing keys return a *ConflictError.
 func (t *Tree) SetWithParams(key string, value interface{}) (err error) {
-	return t.set(key, value, true)
+	if t.optional == 0 || strings.IndexByte(key, t.optional) == -1 {
+		return t.set(t.foldKey(key), t.leaf(key, value), true)
+	}
+
+	keys, err := t.expandOptional(key)
+	if err != nil {
+		return err
+	}
+
+	return t.setAll(keys, value)
+}
+
+// CheckPattern checks if the given key can be set with SetWithParams,
+// returning the same errors as SetWithParams without modifying the tree.
+func (t *Tree) CheckPattern(key string) (err error) {
+	keys := []string{key}
+	if t.optional != 0 && strings.IndexByte(key, t.optional) > -1 {
+		if keys, err = t.expandOptional(key); err != nil {
+			return err
+		}
+	}
+
+	for x := 0; x < len(keys); x++ {
+		if keys[x] == "" {
+			return ErrEmptyKey
+		}
+
+		folded := t.foldKey(keys[x])
+		if err = t.validateKey(folded); err != nil {
+			return err
+		}
+
+		if _, err = t.conflict(folded, t.leaf(keys[x], struct{}{})); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Delete removes the provided key from the tree.
 // It returns false if the key was not found.
 func (t *Tree) Delete(key string) (err error) {
-	return t.delete(key, false)
+	return t.delete(t.foldKey(key), false)
 }
 
 // DeletePrefix deletes all keys under the given prefix
 func (t *Tree) DeletePrefix(key string) (err error) {
-	return t.delete(key, true)
+	return t.delete(t.foldKey(key), true)
 }
 
 // Iter calls f sequentially for each key and value present in the tree.
@@ -89,6 +203,18 @@ func (t *Tree) Iter(f func(key string, value interface{}) bool) {
 	t.root.iter("", f)
 }
 
+// Routes returns all keys stored in the tree in ascending lexicographic order,
+// parsed into their static, parameter and wildcard segments
+func (t *Tree) Routes() (routes []Route) {
+	routes = make([]Route, 0, t.size)
+	t.Iter(func(key string, value interface{}) bool {
+		routes = append(routes, t.route(key, value))
+		return true
+	})
+
+	return routes
+}
+
 // Size returns the number of leaf nodes in the tree
 func (t *Tree) Size() (sz uint64) {
 	return t.size